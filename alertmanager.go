@@ -0,0 +1,131 @@
+package ilert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// alertmanagerWebhook is the payload of a Prometheus Alertmanager webhook https://prometheus.io/docs/alerting/latest/configuration/#webhook_config
+type alertmanagerWebhook struct {
+	Alerts []alertmanagerAlert `json:"alerts"`
+}
+
+// alertmanagerAlert is a single alert of an Alertmanager webhook
+type alertmanagerAlert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     string            `json:"startsAt"`
+	EndsAt       string            `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+// CreateEventFromAlertmanager sends an event for every alert of a Prometheus Alertmanager webhook payload to the
+// alert source with the given api key and returns the sent events. Firing alerts become ALERT events, resolved
+// alerts RESOLVE events. The alert fingerprint is used as incident key, so a resolved alert resolves the incident
+// of the firing one. The summary is the summary annotation or, if missing, built from the alertname and instance
+// labels. Alerts with a critical severity label get HIGH priority, warning and info LOW.
+// Events are sent in the order of the alerts; on failure the events sent so far are returned with the error
+func (c *Client) CreateEventFromAlertmanager(apiKey string, payload []byte) ([]*Event, error) {
+	if apiKey == "" {
+		return nil, errors.New("api key is required")
+	}
+
+	webhook := &alertmanagerWebhook{}
+	if err := json.Unmarshal(payload, webhook); err != nil {
+		return nil, fmt.Errorf("invalid alertmanager payload: %w", err)
+	}
+
+	events := make([]*Event, 0, len(webhook.Alerts))
+	for i, alert := range webhook.Alerts {
+		event, err := newAlertmanagerEvent(apiKey, alert)
+		if err != nil {
+			return nil, fmt.Errorf("alert %d: %w", i, err)
+		}
+		events = append(events, event)
+	}
+
+	sent := make([]*Event, 0, len(events))
+	for _, event := range events {
+		_, err := c.CreateEvent(&CreateEventInput{Event: event})
+		if err != nil {
+			return sent, fmt.Errorf("alert %s: %w", event.IncidentKey, err)
+		}
+		sent = append(sent, event)
+	}
+
+	return sent, nil
+}
+
+func newAlertmanagerEvent(apiKey string, alert alertmanagerAlert) (*Event, error) {
+	event := &Event{
+		APIKey:      apiKey,
+		IncidentKey: alert.Fingerprint,
+	}
+
+	switch alert.Status {
+	case "firing":
+		event.EventType = EventTypes.Alert
+	case "resolved":
+		event.EventType = EventTypes.Resolve
+	default:
+		return nil, fmt.Errorf("invalid alert status %q", alert.Status)
+	}
+
+	if event.IncidentKey == "" {
+		event.IncidentKey = alertmanagerLabelsKey(alert.Labels)
+	}
+
+	event.Summary = alert.Annotations["summary"]
+	if event.Summary == "" {
+		event.Summary = alert.Labels["alertname"]
+		if instance := alert.Labels["instance"]; instance != "" {
+			event.Summary = fmt.Sprintf("%s on %s", event.Summary, instance)
+		}
+	}
+	if event.Summary == "" {
+		event.Summary = event.IncidentKey
+	}
+	event.Details = alert.Annotations["description"]
+
+	switch strings.ToLower(alert.Labels["severity"]) {
+	case "critical":
+		event.Priority = IncidentPriorities.High
+	case "warning", "info":
+		event.Priority = IncidentPriorities.Low
+	}
+
+	if alert.GeneratorURL != "" {
+		event.Links = []IncidentLink{{Text: "Prometheus", Href: alert.GeneratorURL}}
+	}
+
+	event.CustomDetails = map[string]interface{}{
+		"labels":      alert.Labels,
+		"annotations": alert.Annotations,
+		"startsAt":    alert.StartsAt,
+	}
+	if alert.Status == "resolved" {
+		event.CustomDetails["endsAt"] = alert.EndsAt
+	}
+
+	return event, nil
+}
+
+// alertmanagerLabelsKey derives a stable incident key from the alert labels for alerts without fingerprint
+func alertmanagerLabelsKey(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+labels[key])
+	}
+	return strings.Join(pairs, ",")
+}
@@ -1,7 +1,6 @@
 package ilert
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 )
@@ -36,6 +35,12 @@ type AlertSource struct {
 	Teams                  []TeamShort            `json:"teams,omitempty"`
 }
 
+// AlertSourceShort definition
+type AlertSourceShort struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
 // EmailPredicate definition
 type EmailPredicate struct {
 	Field    string `json:"field"`
@@ -275,16 +280,16 @@ func (c *Client) CreateAlertSource(input *CreateAlertSourceInput) (*CreateAlertS
 	if input.AlertSource == nil {
 		return nil, errors.New("alert source input is required")
 	}
-	resp, err := c.httpClient.R().SetBody(input.AlertSource).Post(apiRoutes.alertSources)
+	resp, err := c.newRequest().SetBody(input.AlertSource).Post(apiRoutes.alertSources)
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 201); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 201); apiErr != nil {
 		return nil, apiErr
 	}
 
 	alertSource := &AlertSource{}
-	err = json.Unmarshal(resp.Body(), alertSource)
+	err = decodeResponse("CreateAlertSource", resp, alertSource)
 	if err != nil {
 		return nil, err
 	}
@@ -292,6 +297,37 @@ func (c *Client) CreateAlertSource(input *CreateAlertSourceInput) (*CreateAlertS
 	return &CreateAlertSourceOutput{AlertSource: alertSource}, nil
 }
 
+// CreateAlertSourceWithPolicy creates a new alert source that is bound to the escalation policy with specified id.
+// The policy is part of the create request, so the alert source never exists without it.
+// With WithStrictMode the existence of the escalation policy is checked before the alert source is created
+func (c *Client) CreateAlertSourceWithPolicy(source *AlertSource, policyID int64) (*AlertSource, error) {
+	if source == nil {
+		return nil, errors.New("alert source input is required")
+	}
+	if policyID <= 0 {
+		return nil, errors.New("escalation policy id is required")
+	}
+	if c.strictMode {
+		exists, err := c.EscalationPolicyExists(policyID)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, fmt.Errorf("escalation policy %d: %w", policyID, ErrNotFound)
+		}
+	}
+
+	alertSource := *source
+	alertSource.EscalationPolicy = &EscalationPolicy{ID: policyID}
+
+	output, err := c.CreateAlertSource(&CreateAlertSourceInput{AlertSource: &alertSource})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.AlertSource, nil
+}
+
 // GetAlertSourceInput represents the input of a GetAlertSource operation.
 type GetAlertSourceInput struct {
 	_             struct{}
@@ -313,16 +349,16 @@ func (c *Client) GetAlertSource(input *GetAlertSourceInput) (*GetAlertSourceOutp
 		return nil, errors.New("AlertSource id is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d", apiRoutes.alertSources, *input.AlertSourceID))
+	resp, err := c.newRequest().Get(fmt.Sprintf("%s/%d", apiRoutes.alertSources, *input.AlertSourceID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	alertSource := &AlertSource{}
-	err = json.Unmarshal(resp.Body(), alertSource)
+	err = decodeResponse("GetAlertSource", resp, alertSource)
 	if err != nil {
 		return nil, err
 	}
@@ -343,16 +379,16 @@ type GetAlertSourcesOutput struct {
 
 // GetAlertSources lists alert sources. https://api.ilert.com/api-docs/#tag/Alert-Sources/paths/~1alert-sources/get
 func (c *Client) GetAlertSources(input *GetAlertSourcesInput) (*GetAlertSourcesOutput, error) {
-	resp, err := c.httpClient.R().Get(apiRoutes.alertSources)
+	resp, err := c.newRequest().Get(apiRoutes.alertSources)
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	alertSources := make([]*AlertSource, 0)
-	err = json.Unmarshal(resp.Body(), &alertSources)
+	err = decodeResponse("GetAlertSources", resp, &alertSources)
 	if err != nil {
 		return nil, err
 	}
@@ -385,16 +421,16 @@ func (c *Client) UpdateAlertSource(input *UpdateAlertSourceInput) (*UpdateAlertS
 		return nil, errors.New("alert source id is required")
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.AlertSource).Put(fmt.Sprintf("%s/%d", apiRoutes.alertSources, *input.AlertSourceID))
+	resp, err := c.newRequest().SetBody(input.AlertSource).Put(fmt.Sprintf("%s/%d", apiRoutes.alertSources, *input.AlertSourceID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	alertSource := &AlertSource{}
-	err = json.Unmarshal(resp.Body(), alertSource)
+	err = decodeResponse("UpdateAlertSource", resp, alertSource)
 	if err != nil {
 		return nil, err
 	}
@@ -422,11 +458,11 @@ func (c *Client) DeleteAlertSource(input *DeleteAlertSourceInput) (*DeleteAlertS
 		return nil, errors.New("AlertSource id is required")
 	}
 
-	resp, err := c.httpClient.R().Delete(fmt.Sprintf("%s/%d", apiRoutes.alertSources, *input.AlertSourceID))
+	resp, err := c.newRequest().Delete(fmt.Sprintf("%s/%d", apiRoutes.alertSources, *input.AlertSourceID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 204); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 204); apiErr != nil {
 		return nil, apiErr
 	}
 
@@ -0,0 +1,238 @@
+package ilert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// defaultBulkConcurrency is the number of parallel requests of bulk operations
+const defaultBulkConcurrency = 5
+
+// BulkError is returned by bulk operations if at least one item failed.
+// Errors is index aligned with the items of the input, nil for items that succeeded
+type BulkError struct {
+	Errors []error
+
+	// the error that occurred first, which stopped the operation with StopOnError
+	first error
+}
+
+func (e *BulkError) Error() string {
+	failed := 0
+	for _, err := range e.Errors {
+		if err != nil {
+			failed++
+		}
+	}
+	return fmt.Sprintf("%d of %d items failed, first error: %v", failed, len(e.Errors), e.first)
+}
+
+// Unwrap returns the error that occurred first
+func (e *BulkError) Unwrap() error {
+	return e.first
+}
+
+// batchExecute calls fn for the items 0 to n-1 with at most concurrency calls in parallel. Requests are throttled
+// by the rate limit of the client, see WithRateLimit. Once the context is cancelled no new calls are started and
+// the remaining items fail with the context error. With stopOnError the first failed item cancels the context
+// passed to the other calls. Returns nil if all items succeeded, otherwise a *BulkError
+func batchExecute(ctx context.Context, n int, concurrency int, stopOnError bool, fn func(ctx context.Context, i int) error) error {
+	if concurrency <= 0 {
+		concurrency = defaultBulkConcurrency
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make([]error, n)
+	var first error
+	mu := sync.Mutex{}
+	sem := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		if ctx.Err() != nil {
+			for j := i; j < n; j++ {
+				errs[j] = ctx.Err()
+			}
+			break
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := fn(ctx, i)
+			errs[i] = err
+			if err == nil {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if first == nil {
+				first = err
+				if stopOnError {
+					cancel()
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			if first == nil {
+				first = err
+			}
+			return &BulkError{Errors: errs, first: first}
+		}
+	}
+	return nil
+}
+
+// firstError returns the error that occurred first if err is a *BulkError, otherwise err
+func firstError(err error) error {
+	bulkErr := &BulkError{}
+	if errors.As(err, &bulkErr) {
+		return bulkErr.first
+	}
+	return err
+}
+
+// ResolveIncidentsInput represents the input of a ResolveIncidents operation.
+type ResolveIncidentsInput struct {
+	_           struct{}
+	IncidentIDs []int64
+
+	// (optional) maximum number of requests in parallel. Default: 5
+	Concurrency *int
+
+	// (optional) stop at the first failed item instead of processing all items. Requests in flight are cancelled
+	// and the items not processed fail with context.Canceled. Default: false
+	StopOnError bool
+}
+
+// ResolveIncidentsOutput represents the output of a ResolveIncidents operation.
+type ResolveIncidentsOutput struct {
+	_ struct{}
+
+	// the resolved incidents, index aligned with the incident ids of the input, nil for failed incidents
+	Incidents []*Incident
+}
+
+// ResolveIncidents resolves multiple incidents in parallel. If any incident fails, the output is returned
+// together with a *BulkError holding the error of each incident
+func (c *Client) ResolveIncidents(ctx context.Context, input *ResolveIncidentsInput) (*ResolveIncidentsOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+
+	incidents := make([]*Incident, len(input.IncidentIDs))
+	err := batchExecute(ctx, len(input.IncidentIDs), bulkConcurrency(input.Concurrency), input.StopOnError, func(ctx context.Context, i int) error {
+		output, err := c.withContext(ctx).ResolveIncident(&ResolveIncidentInput{IncidentID: Int64(input.IncidentIDs[i])})
+		if err != nil {
+			return fmt.Errorf("incident %d: %w", input.IncidentIDs[i], err)
+		}
+		incidents[i] = output.Incident
+		return nil
+	})
+
+	return &ResolveIncidentsOutput{Incidents: incidents}, err
+}
+
+// AssignIncidentsInput represents the input of a AssignIncidents operation.
+type AssignIncidentsInput struct {
+	_           struct{}
+	IncidentIDs []int64
+
+	// the assignment of all incidents, its IncidentID is ignored
+	Assignment *AssignIncidentInput
+
+	// (optional) maximum number of requests in parallel. Default: 5
+	Concurrency *int
+
+	// (optional) stop at the first failed item instead of processing all items. Requests in flight are cancelled
+	// and the items not processed fail with context.Canceled. Default: false
+	StopOnError bool
+}
+
+// AssignIncidentsOutput represents the output of a AssignIncidents operation.
+type AssignIncidentsOutput struct {
+	_ struct{}
+
+	// the assigned incidents, index aligned with the incident ids of the input, nil for failed incidents
+	Incidents []*Incident
+}
+
+// AssignIncidents assigns multiple incidents in parallel. If any incident fails, the output is returned
+// together with a *BulkError holding the error of each incident
+func (c *Client) AssignIncidents(ctx context.Context, input *AssignIncidentsInput) (*AssignIncidentsOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if input.Assignment == nil {
+		return nil, errors.New("assignment is required")
+	}
+
+	incidents := make([]*Incident, len(input.IncidentIDs))
+	err := batchExecute(ctx, len(input.IncidentIDs), bulkConcurrency(input.Concurrency), input.StopOnError, func(ctx context.Context, i int) error {
+		assignment := *input.Assignment
+		assignment.IncidentID = Int64(input.IncidentIDs[i])
+		output, err := c.withContext(ctx).AssignIncident(&assignment)
+		if err != nil {
+			return fmt.Errorf("incident %d: %w", input.IncidentIDs[i], err)
+		}
+		incidents[i] = output.Incident
+		return nil
+	})
+
+	return &AssignIncidentsOutput{Incidents: incidents}, err
+}
+
+// DeleteConnectorsInput represents the input of a DeleteConnectors operation.
+type DeleteConnectorsInput struct {
+	_            struct{}
+	ConnectorIDs []string
+
+	// (optional) maximum number of requests in parallel. Default: 5
+	Concurrency *int
+
+	// (optional) stop at the first failed item instead of processing all items. Requests in flight are cancelled
+	// and the items not processed fail with context.Canceled. Default: false
+	StopOnError bool
+}
+
+// DeleteConnectorsOutput represents the output of a DeleteConnectors operation.
+type DeleteConnectorsOutput struct {
+	_ struct{}
+}
+
+// DeleteConnectors deletes multiple connectors in parallel. If any connector fails, the output is returned
+// together with a *BulkError holding the error of each connector
+func (c *Client) DeleteConnectors(ctx context.Context, input *DeleteConnectorsInput) (*DeleteConnectorsOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+
+	err := batchExecute(ctx, len(input.ConnectorIDs), bulkConcurrency(input.Concurrency), input.StopOnError, func(ctx context.Context, i int) error {
+		_, err := c.withContext(ctx).DeleteConnector(&DeleteConnectorInput{ConnectorID: String(input.ConnectorIDs[i])})
+		if err != nil {
+			return fmt.Errorf("connector %s: %w", input.ConnectorIDs[i], err)
+		}
+		return nil
+	})
+
+	return &DeleteConnectorsOutput{}, err
+}
+
+func bulkConcurrency(concurrency *int) int {
+	if concurrency == nil {
+		return defaultBulkConcurrency
+	}
+	return *concurrency
+}
@@ -1,27 +1,56 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 )
 
 const (
-	apiEndpoint  = "https://api.ilert.com"
-	apiTimeoutMs = 30000
+	apiEndpoint       = "https://api.ilert.com"
+	apiTimeoutMs      = 30000
+	apiErrorBodyLimit = 512
+	maxRetryCount     = 100
 )
 
 // Client wraps http client
 type Client struct {
-	apiEndpoint string
-	httpClient  *resty.Client
+	apiEndpoint         string
+	httpClient          *resty.Client
+	customDetailsSchema map[string]string
+	errorBodyLimit      int
+	retryCount          int
+	strictMode          bool
+	currentUser         *userIDCache
+	metricsObserver     MetricsObserver
+	rateLimiter         *rateLimiter
+	logger              resty.Logger
+	timeLayouts         []string
+	retryBudget         *retryBudget
+	defaultTeamID       *int64
+	ctx                 context.Context
 }
 
-// GenericAPIError describes generic API response error e.g. bad request
+// userIDCache holds the id of the currently authenticated user
+type userIDCache struct {
+	mu sync.Mutex
+	id *int64
+}
+
+// retryCountContextKey is the request context key of the retry count used by the retry condition
+type retryCountContextKey struct{}
+
+// GenericAPIError describes generic API response error e.g. bad request.
+// All operations return API errors as *GenericAPIError (or *RateLimitError wrapping it), use errors.As or the
+// helpers IsNotFound, IsUnauthorized and IsForbidden to check the status
 type GenericAPIError struct {
 	error
 	Status  int    `json:"status"`
@@ -33,21 +62,79 @@ func (aerr *GenericAPIError) Error() string {
 	return fmt.Sprintf("Error occurred with status code: %d, error code: %s, message: %s", aerr.Status, aerr.Code, aerr.Message)
 }
 
+// RateLimitError is returned when the API still answers 429 Too Many Requests after all retries,
+// or immediately if retries are disabled. RetryAfter is the wait time requested by the Retry-After header, 0 if missing
+type RateLimitError struct {
+	*GenericAPIError
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s, retry after: %s", e.GenericAPIError.Error(), e.RetryAfter)
+}
+
+// Unwrap allows errors.As(err, &genericAPIError)
+func (e *RateLimitError) Unwrap() error {
+	return e.GenericAPIError
+}
+
+// DefaultMaxResults is the number of results the API returns for list operations when no max results are given
+const DefaultMaxResults = 50
+
+// MaxPageSize is the largest number of results the API returns per page, larger max results are clamped to it
+const MaxPageSize = 100
+
+// clampPageSize limits max results to MaxPageSize and logs a warning if it was clamped
+func (c *Client) clampPageSize(operation string, maxResults *int) *int {
+	if maxResults == nil || *maxResults <= MaxPageSize {
+		return maxResults
+	}
+	if c.logger != nil {
+		c.logger.Warnf("%s: max results %d exceeds the maximum page size, using %d", operation, *maxResults, MaxPageSize)
+	}
+	return Int(MaxPageSize)
+}
+
+// ErrNotFound is returned by the lookup helpers (e.g. GetEscalationPolicyByName, GetUserByEmail)
+// when no resource matches. Note: a 404 response of the API is returned as *GenericAPIError with status 404 instead.
+var ErrNotFound = errors.New("resource not found")
+
+// ErrMultipleMatches is returned by the lookup helpers when more than one resource matches
+var ErrMultipleMatches = errors.New("multiple resources found")
+
 // GenericCountResponse describes generic resources count response
 type GenericCountResponse struct {
 	Count int `json:"count"`
 }
 
-func retryCondition(r *resty.Response, err error) bool {
-	return err != nil ||
+// retryCondition decides if a request is retried. The number of retries is limited by the retry count of the
+// client that created the request, resty itself is configured with maxRetryCount
+func (c *Client) retryCondition(r *resty.Response, err error) bool {
+	if r == nil || r.Request == nil {
+		return false
+	}
+	retryCount := c.retryCount
+	if v, ok := r.Request.Context().Value(retryCountContextKey{}).(int); ok {
+		retryCount = v
+	}
+	if r.Request.Attempt > retryCount || errors.Is(err, ErrCompressedResponse) {
+		return false
+	}
+
+	retry := err != nil ||
 		r.StatusCode() == http.StatusTooManyRequests ||
 		r.StatusCode() >= http.StatusInternalServerError
+
+	return retry && c.allowRetry(r)
 }
 
 // NewClient creates an API client using an API token
 func NewClient(options ...ClientOptions) *Client {
 	c := Client{
-		apiEndpoint: apiEndpoint,
+		apiEndpoint:    apiEndpoint,
+		errorBodyLimit: apiErrorBodyLimit,
+		retryCount:     4,
+		currentUser:    &userIDCache{},
 	}
 
 	c.httpClient = resty.New()
@@ -57,13 +144,17 @@ func NewClient(options ...ClientOptions) *Client {
 	c.httpClient.SetHeader("Content-Type", "application/json")
 	c.httpClient.SetHeader("User-Agent", fmt.Sprintf("ilert-go/%s", Version))
 	c.httpClient.SetHeader("Accept-Encoding", "gzip")
-	c.httpClient.SetRetryCount(4).
+	c.httpClient.OnAfterResponse(checkCompressedResponse)
+	c.httpClient.SetRetryCount(maxRetryCount).
 		SetRetryWaitTime(1 * time.Second).
 		SetRetryMaxWaitTime(5 * time.Second).
-		AddRetryCondition(retryCondition)
+		SetRetryAfter(retryAfter).
+		AddRetryCondition(c.retryCondition).
+		AddRetryHook(c.retryHook)
 
 	endpoint := getEnv("ILERT_ENDPOINT")
 	if endpoint != nil {
+		c.apiEndpoint = *endpoint
 		c.httpClient.SetHostURL(*endpoint)
 	}
 
@@ -85,6 +176,20 @@ func NewClient(options ...ClientOptions) *Client {
 	return &c
 }
 
+// Endpoint returns the API endpoint the client sends its requests to,
+// either the default endpoint or the one set by ILERT_ENDPOINT or WithAPIEndpoint
+func (c *Client) Endpoint() string {
+	return c.apiEndpoint
+}
+
+// HTTPClient returns the underlying resty client for settings without a client option, e.g. cookies,
+// redirect policies or custom root certificates. Prefer the client options where one exists.
+// The resty client is shared by all requests and clones of this client, so change it before the client is used
+// concurrently, and do not override the authentication, retry condition or middlewares set up by NewClient
+func (c *Client) HTTPClient() *resty.Client {
+	return c.httpClient
+}
+
 // ClientOptions allows for options to be passed into the Client for customization
 type ClientOptions func(*Client)
 
@@ -124,25 +229,203 @@ func WithProxy(url string) ClientOptions {
 	}
 }
 
-// WithRetry enables retry logic with exponential backoff for the following errors:
+// WithRequestMiddleware registers a middleware that is called before each request is sent.
+// Request middlewares run in the order they were registered and before the built-in middlewares of resty,
+// which means the request URL, the default headers and the authentication are not applied yet.
+// With retries enabled the middleware is called for every attempt.
+func WithRequestMiddleware(fn resty.RequestMiddleware) ClientOptions {
+	return func(c *Client) {
+		c.httpClient.OnBeforeRequest(fn)
+	}
+}
+
+// WithResponseMiddleware registers a middleware that is called after each response is received.
+// Response middlewares run in the order they were registered, after the body has been read and decompressed
+// and before the retry condition is evaluated. Returning an error fails the attempt with that error.
+// With retries enabled the middleware is called for every attempt.
+func WithResponseMiddleware(fn resty.ResponseMiddleware) ClientOptions {
+	return func(c *Client) {
+		c.httpClient.OnAfterResponse(fn)
+	}
+}
+
+// WithRetry enables retry logic with exponential backoff and jitter, starting at retryWaitTime and capped at
+// retryMaxWaitTime, for the following errors:
 //
 // - any network errors
 //
 // - 5xx errors: this indicates an error in iLert
 //
-// - 429 Too Many Requests: you have reached your rate limit
+// - 429 Too Many Requests: you have reached your rate limit, the Retry-After header is honored up to retryMaxWaitTime
+//
+// Other 4xx errors are never retried. Without this option the client retries up to 4 times.
+// The retry count can be overridden per request using Client.WithMaxRetries
 func WithRetry(retryCount int, retryWaitTime time.Duration, retryMaxWaitTime time.Duration) ClientOptions {
 	return func(c *Client) {
+		c.retryCount = retryCount
 		c.httpClient.
-			SetRetryCount(retryCount).
 			SetRetryWaitTime(retryWaitTime).
-			SetRetryMaxWaitTime(retryMaxWaitTime).
-			AddRetryCondition(retryCondition)
+			SetRetryMaxWaitTime(retryMaxWaitTime)
+	}
+}
+
+// WithMaxRetries returns a copy of the client that sends its requests with the given retry count.
+// The copy shares the underlying http client, authentication and all other settings with the original client,
+// only the retry count set by WithRetry is overridden for requests made through the copy (up to 100 retries), e.g.
+//
+//	client.WithMaxRetries(10).ResolveIncident(input)
+func (c *Client) WithMaxRetries(maxRetries int) *Client {
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+	if maxRetries > maxRetryCount {
+		maxRetries = maxRetryCount
+	}
+	clone := *c
+	clone.retryCount = maxRetries
+	return &clone
+}
+
+// withContext returns a copy of the client that sends all requests with the given context
+func (c *Client) withContext(ctx context.Context) *Client {
+	clone := *c
+	clone.ctx = ctx
+	return &clone
+}
+
+// newRequest creates a new request carrying the retry count of the client
+func (c *Client) newRequest() *resty.Request {
+	if c.ctx != nil {
+		return c.newRequestWithContext(c.ctx)
+	}
+	return c.newRequestWithContext(context.Background())
+}
+
+// newRequestWithContext creates a new request with the given context carrying the retry count of the client
+func (c *Client) newRequestWithContext(ctx context.Context) *resty.Request {
+	return c.httpClient.R().SetContext(context.WithValue(ctx, retryCountContextKey{}, c.retryCount))
+}
+
+// WithCustomDetailsSchema enables validation of custom details against the given schema before an event is created.
+// The schema maps each required custom details key to its type, see CustomDetailsTypes
+func WithCustomDetailsSchema(schema map[string]string) ClientOptions {
+	return func(c *Client) {
+		c.customDetailsSchema = schema
 	}
 }
 
-// getGenericAPIError extract API response error
-func getGenericAPIError(response *resty.Response, expectedStatusCode ...int) *GenericAPIError {
+// WithErrorBodyLimit sets the maximum number of bytes of a non JSON error response body (e.g. an HTML page of a proxy)
+// that is included in the error message. Default: 512
+func WithErrorBodyLimit(limit int) ClientOptions {
+	return func(c *Client) {
+		c.errorBodyLimit = limit
+	}
+}
+
+// WithCompression enables or disables gzip compression of the responses. Default: enabled
+func WithCompression(enabled bool) ClientOptions {
+	return func(c *Client) {
+		if enabled {
+			c.httpClient.SetHeader("Accept-Encoding", "gzip")
+		} else {
+			c.httpClient.SetHeader("Accept-Encoding", "identity")
+		}
+	}
+}
+
+// ErrCompressedResponse is returned when a response body is gzip compressed but was not decompressed,
+// usually because a proxy removed the Content-Encoding header
+var ErrCompressedResponse = errors.New("response body is gzip compressed but has no gzip Content-Encoding header (e.g. removed by a proxy), disable compression with WithCompression(false)")
+
+// checkCompressedResponse fails responses whose body still starts with the gzip magic bytes,
+// which would otherwise surface as a cryptic JSON syntax error
+func checkCompressedResponse(_ *resty.Client, r *resty.Response) error {
+	body := r.Body()
+	if len(body) >= 2 && body[0] == 0x1f && body[1] == 0x8b {
+		return ErrCompressedResponse
+	}
+	return nil
+}
+
+// WithLogger sets the logger of the client, it receives the logs of the http client
+// and warnings e.g. about list results that were probably truncated
+func WithLogger(logger resty.Logger) ClientOptions {
+	return func(c *Client) {
+		c.logger = logger
+		c.httpClient.SetLogger(logger)
+	}
+}
+
+// WithTimeLayouts adds time layouts (see time.Parse) that are tried when a timestamp of the API does not match
+// any of the known ISO 8601 layouts
+func WithTimeLayouts(layouts ...string) ClientOptions {
+	return func(c *Client) {
+		c.timeLayouts = append(c.timeLayouts, layouts...)
+	}
+}
+
+// parseTime parses a timestamp of the API with the known and the configured time layouts
+func (c *Client) parseTime(s string) (time.Time, error) {
+	return parseILERTTime(s, c.timeLayouts...)
+}
+
+// WithStrictMode enables client side validation of resources before they are sent to the API,
+// e.g. connectors are normalized with NormalizeConnectorParams and checked with ValidateConnector on create and update,
+// connections are checked with ValidateConnection
+func WithStrictMode() ClientOptions {
+	return func(c *Client) {
+		c.strictMode = true
+	}
+}
+
+// WithDefaultTeam scopes list operations (incidents, escalation policies, schedules) to the team with the given id.
+// The team filter is only applied when the TeamIDs of the input are nil, pass an empty non-nil slice,
+// e.g. TeamIDs: []*int64{}, to list the resources of all teams. Lookups by name and ExportAll always cover all teams
+func WithDefaultTeam(teamID int64) ClientOptions {
+	return func(c *Client) {
+		c.defaultTeamID = Int64(teamID)
+	}
+}
+
+// teamIDsOrDefault returns the given team ids, or the default team if no team ids were given
+func (c *Client) teamIDsOrDefault(teamIDs []*int64) []*int64 {
+	if teamIDs == nil && c.defaultTeamID != nil {
+		return []*int64{c.defaultTeamID}
+	}
+	return teamIDs
+}
+
+// decodeResponse unmarshals the response body into v. Errors are wrapped with the operation and the request url,
+// e.g. "GetIncident: decoding response from https://api.ilert.com/api/incidents/1: json: cannot unmarshal ..."
+func decodeResponse(operation string, resp *resty.Response, v interface{}) error {
+	if err := json.Unmarshal(resp.Body(), v); err != nil {
+		return fmt.Errorf("%s: decoding response from %s: %w", operation, resp.Request.URL, err)
+	}
+	return nil
+}
+
+// getGenericAPIError extract API response error, unexpected 429 responses are returned as *RateLimitError
+func (c *Client) getGenericAPIError(response *resty.Response, expectedStatusCode ...int) error {
+	apiErr := c.parseGenericAPIError(response, expectedStatusCode...)
+	if response.StatusCode() == http.StatusTooManyRequests && !intSliceContains(expectedStatusCode, http.StatusTooManyRequests) {
+		if apiErr == nil {
+			apiErr = &GenericAPIError{
+				Status:  response.StatusCode(),
+				Code:    "ERROR",
+				Message: c.getErrorBodyMessage(response.Body()),
+			}
+		}
+		retryAfter, _ := parseRetryAfter(response.Header().Get("Retry-After"), time.Now())
+		return &RateLimitError{GenericAPIError: apiErr, RetryAfter: retryAfter}
+	}
+	if apiErr == nil {
+		return nil
+	}
+	return apiErr
+}
+
+// parseGenericAPIError parses the API error of a response with an unexpected status code
+func (c *Client) parseGenericAPIError(response *resty.Response, expectedStatusCode ...int) *GenericAPIError {
 	if !intSliceContains(expectedStatusCode, response.StatusCode()) {
 		out := &GenericAPIError{}
 		err := json.Unmarshal(response.Body(), out)
@@ -150,18 +433,53 @@ func getGenericAPIError(response *resty.Response, expectedStatusCode ...int) *Ge
 			return &GenericAPIError{
 				Status:  response.StatusCode(),
 				Code:    "ERROR",
-				Message: "An error occurred",
+				Message: c.getErrorBodyMessage(response.Body()),
 			}
 		}
 		if out.Message == "" {
 			return nil
 		}
+		if out.Status == 0 {
+			out.Status = response.StatusCode()
+		}
 		return out
 	}
 
 	return nil
 }
 
+// IsNotFound checks if the error is an API error with status 404 Not Found
+func IsNotFound(err error) bool {
+	return hasAPIErrorStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized checks if the error is an API error with status 401 Unauthorized, e.g. an invalid api token
+func IsUnauthorized(err error) bool {
+	return hasAPIErrorStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden checks if the error is an API error with status 403 Forbidden, e.g. missing permissions
+func IsForbidden(err error) bool {
+	return hasAPIErrorStatus(err, http.StatusForbidden)
+}
+
+func hasAPIErrorStatus(err error, status int) bool {
+	var apiErr *GenericAPIError
+	return errors.As(err, &apiErr) && apiErr.Status == status
+}
+
+// getErrorBodyMessage returns the opaque error response body truncated to the configured limit
+func (c *Client) getErrorBodyMessage(body []byte) string {
+	message := strings.TrimSpace(string(body))
+	if message == "" {
+		return "An error occurred"
+	}
+	if c.errorBodyLimit > 0 && len(message) > c.errorBodyLimit {
+		return fmt.Sprintf("%s... (truncated, %d bytes total)", message[:c.errorBodyLimit], len(message))
+	}
+	return message
+}
+
 // apiRoutes defines api routes
 var apiRoutes = struct {
 	alertSources       string
@@ -171,6 +489,7 @@ var apiRoutes = struct {
 	events             string
 	heartbeats         string
 	incidents          string
+	maintenanceWindows string
 	numbers            string
 	schedules          string
 	uptimeMonitors     string
@@ -184,6 +503,7 @@ var apiRoutes = struct {
 	events:             "/api/v1/events",
 	heartbeats:         "/api/v1/heartbeats",
 	incidents:          "/api/v1/incidents",
+	maintenanceWindows: "/api/v1/maintenance-windows",
 	numbers:            "/api/v1/numbers",
 	schedules:          "/api/v1/schedules",
 	uptimeMonitors:     "/api/v1/uptime-monitors",
@@ -0,0 +1,87 @@
+package ilert
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newFailingServer returns a server that answers the first failures requests with the given status and then 200
+func newFailingServer(failures int32, status int) (*httptest.Server, *int32) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(&requests, 1) <= failures {
+			w.WriteHeader(status)
+			fmt.Fprintf(w, `{"status":%d,"message":%q}`, status, http.StatusText(status))
+			return
+		}
+		w.Write([]byte(`{"id":1}`))
+	}))
+	return server, &requests
+}
+
+func TestWithRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		retryCount   int
+		maxRetries   *int
+		failures     int32
+		status       int
+		wantErr      bool
+		wantRequests int32
+	}{
+		{"server errors are retried", 3, nil, 2, http.StatusServiceUnavailable, false, 3},
+		{"retry count is exhausted", 1, nil, 2, http.StatusServiceUnavailable, true, 2},
+		{"client errors are not retried", 3, nil, 2, http.StatusBadRequest, true, 1},
+		{"retry count of WithMaxRetries", 0, Int(2), 2, http.StatusInternalServerError, false, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := newFailingServer(tt.failures, tt.status)
+			defer server.Close()
+
+			client := NewClient(WithAPIEndpoint(server.URL), WithRetry(tt.retryCount, time.Millisecond, 5*time.Millisecond))
+			if tt.maxRetries != nil {
+				client = client.WithMaxRetries(*tt.maxRetries)
+			}
+			_, err := client.GetIncident(&GetIncidentInput{IncidentID: Int64(1)})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, want error %t", err, tt.wantErr)
+			}
+			if got := atomic.LoadInt32(requests); got != tt.wantRequests {
+				t.Errorf("got %d requests, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestCompressedResponseWithoutContentEncoding(t *testing.T) {
+	compressed := &bytes.Buffer{}
+	zw := gzip.NewWriter(compressed)
+	zw.Write([]byte(`{"id":1}`))
+	zw.Close()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(compressed.Bytes())
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIEndpoint(server.URL), WithRetry(3, time.Millisecond, 5*time.Millisecond))
+	_, err := client.GetIncident(&GetIncidentInput{IncidentID: Int64(1)})
+	if !errors.Is(err, ErrCompressedResponse) {
+		t.Errorf("error = %v, want ErrCompressedResponse", err)
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+}
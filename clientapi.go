@@ -0,0 +1,152 @@
+package ilert
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// ClientAPI describes the operations of the iLert API client. Code that depends on ClientAPI instead of *Client
+// can be tested with a fake implementation, e.g. ilerttest.FakeClient
+type ClientAPI interface {
+	// organization
+	ExportAll(ctx context.Context, w io.Writer, options ...ExportOption) error
+
+	// alert sources
+	CreateAlertSource(input *CreateAlertSourceInput) (*CreateAlertSourceOutput, error)
+	CreateAlertSourceWithPolicy(source *AlertSource, policyID int64) (*AlertSource, error)
+	GetAlertSource(input *GetAlertSourceInput) (*GetAlertSourceOutput, error)
+	GetAlertSources(input *GetAlertSourcesInput) (*GetAlertSourcesOutput, error)
+	UpdateAlertSource(input *UpdateAlertSourceInput) (*UpdateAlertSourceOutput, error)
+	DeleteAlertSource(input *DeleteAlertSourceInput) (*DeleteAlertSourceOutput, error)
+
+	// connections
+	CreateConnection(input *CreateConnectionInput) (*CreateConnectionOutput, error)
+	GetConnection(input *GetConnectionInput) (*GetConnectionOutput, error)
+	GetConnections(input *GetConnectionsInput) (*GetConnectionsOutput, error)
+	UpdateConnection(input *UpdateConnectionInput) (*UpdateConnectionOutput, error)
+	DeleteConnection(input *DeleteConnectionInput) (*DeleteConnectionOutput, error)
+
+	// connectors
+	CreateConnector(input *CreateConnectorInput) (*CreateConnectorOutput, error)
+	GetConnector(input *GetConnectorInput) (*GetConnectorOutput, error)
+	ConnectorExists(id string) (bool, error)
+	GetConnectors(input *GetConnectorsInput) (*GetConnectorsOutput, error)
+	GetConnectorsTyped() ([]ConnectorTyped, error)
+	UpdateConnector(input *UpdateConnectorInput) (*UpdateConnectorOutput, error)
+	UpsertConnector(connector *Connector) (*ConnectorOutput, error)
+	UpdateConnectorSecret(connectorID string, secret string) error
+	DeleteConnector(input *DeleteConnectorInput) (*DeleteConnectorOutput, error)
+	DeleteConnectors(ctx context.Context, input *DeleteConnectorsInput) (*DeleteConnectorsOutput, error)
+	GetConnectorReferences(connectorID string) ([]ConnectorReference, error)
+
+	// escalation policies
+	CreateEscalationPolicy(input *CreateEscalationPolicyInput) (*CreateEscalationPolicyOutput, error)
+	GetEscalationPolicy(input *GetEscalationPolicyInput) (*GetEscalationPolicyOutput, error)
+	GetEscalationPoliciesUsingSchedule(scheduleID int64) ([]*EscalationPolicy, error)
+	GetEscalationPoliciesUsingUser(userID int64) ([]*EscalationPolicy, error)
+	EscalationPolicyExists(id int64) (bool, error)
+	GetEscalationPolicies(input *GetEscalationPoliciesInput) (*GetEscalationPoliciesOutput, error)
+	ResolveEscalationPolicyTeams(escalationPolicies []*EscalationPolicy) error
+	UpdateEscalationPolicy(input *UpdateEscalationPolicyInput) (*UpdateEscalationPolicyOutput, error)
+	DeleteEscalationPolicy(input *DeleteEscalationPolicyInput) (*DeleteEscalationPolicyOutput, error)
+	GetEscalationPolicyByName(name string) (*EscalationPolicy, error)
+	GetEscalationPolicyOnCall(policyID int64) ([]OnCallTarget, error)
+
+	// events
+	CreateEvent(input *CreateEventInput) (*CreateEventOutput, error)
+	CreateEventFromAlertmanager(apiKey string, payload []byte) ([]*Event, error)
+	CreateIncidentIfUnderThreshold(input *CreateEventInput, maxOpen int) (*EventResponse, bool, error)
+	CreateEvents(input *CreateEventsInput) (*CreateEventsOutput, error)
+	CreateEventsWithContext(ctx context.Context, input *CreateEventsInput) (*CreateEventsOutput, error)
+
+	// heartbeats
+	PingHeartbeat(input *PingHeartbeatInput) (*PingHeartbeatOutput, error)
+	PingHeartbeatWithContext(ctx context.Context, input *PingHeartbeatInput) (*PingHeartbeatOutput, error)
+	StartHeartbeatPinger(ctx context.Context, heartbeatKey string, interval time.Duration, onError func(error)) error
+
+	// incidents
+	GetIncident(input *GetIncidentInput) (*GetIncidentOutput, error)
+	GetIncidentWithContext(ctx context.Context, input *GetIncidentInput) (*GetIncidentOutput, error)
+	GetIncidentIfModified(input *GetIncidentInput, since time.Time) (*GetIncidentIfModifiedOutput, error)
+	GetIncidentInto(input *GetIncidentInput, out interface{}) error
+	DownloadIncidentImage(img IncidentImage) ([]byte, string, error)
+	IncidentExists(id int64) (bool, error)
+	WaitForIncidentStatus(ctx context.Context, incidentID int64, target string, pollInterval time.Duration) (*Incident, error)
+	GetIncidents(input *GetIncidentsInput) (*GetIncidentsOutput, error)
+	GetIncidentsInto(input *GetIncidentsInput, out interface{}) error
+	GetIncidentsPaged(input *GetIncidentsInput) ([]*Incident, error)
+	SearchIncidentsByCustomDetail(key, value string, input *GetIncidentsInput) ([]*Incident, error)
+	GetAllIncidents(input *GetAllIncidentsInput) (*GetAllIncidentsOutput, error)
+	ExportIncidents(input *GetIncidentsInput, fields []string, w io.Writer) error
+	GetIncidentsAssignedToMe(input *GetIncidentsInput) (*GetIncidentsOutput, error)
+	GetIncidentsCount(input *GetIncidentsCountInput) (*GetIncidentsCountOutput, error)
+	GetIncidentsCountWithContext(ctx context.Context, input *GetIncidentsCountInput) (*GetIncidentsCountOutput, error)
+	OpenIncidentCount(ctx context.Context) (int, error)
+	GetIncidentTrends(input *IncidentTrendsInput) (*IncidentTrendsOutput, error)
+	GetIncidentResponder(input *GetIncidentResponderInput) (*GetIncidentResponderOutput, error)
+	AssignIncident(input *AssignIncidentInput) (*AssignIncidentOutput, error)
+	AssignIncidents(ctx context.Context, input *AssignIncidentsInput) (*AssignIncidentsOutput, error)
+	AcceptIncident(input *AcceptIncidentInput) (*AcceptIncidentOutput, error)
+	AcceptIncidentByKey(incidentKey string) (*Incident, error)
+	ResolveIncident(input *ResolveIncidentInput) (*ResolveIncidentOutput, error)
+	ResolveIncidents(ctx context.Context, input *ResolveIncidentsInput) (*ResolveIncidentsOutput, error)
+	TakeIncident(input *TakeIncidentInput) (*TakeIncidentOutput, error)
+	UpdateIncident(input *UpdateIncidentInput) (*UpdateIncidentOutput, error)
+	UpdateIncidentPriority(input *UpdateIncidentPriorityInput) (*UpdateIncidentPriorityOutput, error)
+	CreateIncidentComment(input *CreateIncidentCommentInput) (*CreateIncidentCommentOutput, error)
+	AppendIncidentDetails(incidentID int64, text string) (*Incident, error)
+	TraceIncidentRouting(incidentID int64) (*RoutingTrace, error)
+	EscalateStaleIncidents(olderThan time.Duration, fromPriority, toPriority string) (int, error)
+	GetIncidentLogEntries(input *GetIncidentLogEntriesInput) (*GetIncidentLogEntriesOutput, error)
+	GetIncidentActions(input *GetIncidentActionsInput) (*GetIncidentActionsOutput, error)
+	InvokeIncidentAction(input *InvokeIncidentActionInput) (*InvokeIncidentActionOutput, error)
+	WaitForIncidentActionResult(ctx context.Context, incidentID int64, webhookID string, previousResults int, pollInterval time.Duration) (*IncidentActionResult, error)
+
+	// maintenance windows
+	CreateMaintenanceWindow(input *CreateMaintenanceWindowInput) (*CreateMaintenanceWindowOutput, error)
+	GetMaintenanceWindow(input *GetMaintenanceWindowInput) (*GetMaintenanceWindowOutput, error)
+	GetMaintenanceWindows(input *GetMaintenanceWindowsInput) (*GetMaintenanceWindowsOutput, error)
+	UpdateMaintenanceWindow(input *UpdateMaintenanceWindowInput) (*UpdateMaintenanceWindowOutput, error)
+	DeleteMaintenanceWindow(input *DeleteMaintenanceWindowInput) (*DeleteMaintenanceWindowOutput, error)
+
+	// numbers
+	GetNumbers(input *GetNumbersInput) (*GetNumbersOutput, error)
+
+	// schedules
+	GetSchedule(input *GetScheduleInput) (*GetScheduleOutput, error)
+	GetSchedules(input *GetSchedulesInput) (*GetSchedulesOutput, error)
+	GetScheduleByName(name string) (*Schedule, error)
+	GetScheduleShifts(input *GetScheduleShiftsInput) (*GetScheduleShiftsOutput, error)
+	GetScheduleOverrides(input *GetScheduleOverridesInput) (*GetScheduleOverridesOutput, error)
+	GetScheduleUserOnCall(input *GetScheduleUserOnCallInput) (*GetScheduleUserOnCallOutput, error)
+
+	// teams
+	CreateTeam(input *CreateTeamInput) (*CreateTeamOutput, error)
+	GetTeam(input *GetTeamInput) (*GetTeamOutput, error)
+	GetTeams(input *GetTeamsInput) (*GetTeamsOutput, error)
+	UpdateTeam(input *UpdateTeamInput) (*UpdateTeamOutput, error)
+	DeleteTeam(input *DeleteTeamInput) (*DeleteTeamOutput, error)
+
+	// uptime monitors
+	CreateUptimeMonitor(input *CreateUptimeMonitorInput) (*CreateUptimeMonitorOutput, error)
+	GetUptimeMonitor(input *GetUptimeMonitorInput) (*GetUptimeMonitorOutput, error)
+	GetUptimeMonitors(input *GetUptimeMonitorsInput) (*GetUptimeMonitorsOutput, error)
+	UpdateUptimeMonitor(input *UpdateUptimeMonitorInput) (*UpdateUptimeMonitorOutput, error)
+	PauseUptimeMonitor(input *PauseUptimeMonitorInput) (*PauseUptimeMonitorOutput, error)
+	ResumeUptimeMonitor(input *ResumeUptimeMonitorInput) (*ResumeUptimeMonitorOutput, error)
+	DeleteUptimeMonitor(input *DeleteUptimeMonitorInput) (*DeleteUptimeMonitorOutput, error)
+	GetUptimeMonitorsCount(input *GetUptimeMonitorsCountInput) (*GetUptimeMonitorsCountOutput, error)
+
+	// users
+	CreateUser(input *CreateUserInput) (*CreateUserOutput, error)
+	GetCurrentUser() (*GetUserOutput, error)
+	GetUser(input *GetUserInput) (*GetUserOutput, error)
+	GetUsers(input *GetUsersInput) (*GetUsersOutput, error)
+	UpdateCurrentUser(input *UpdateUserInput) (*UpdateUserOutput, error)
+	UpdateUser(input *UpdateUserInput) (*UpdateUserOutput, error)
+	DeleteUser(input *DeleteUserInput) (*DeleteUserOutput, error)
+	GetUserByEmail(email string) (*User, error)
+}
+
+var _ ClientAPI = (*Client)(nil)
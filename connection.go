@@ -1,9 +1,9 @@
 package ilert
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 )
 
 // Connection definition https://api.ilert.com/api-docs/#!/Connections
@@ -13,10 +13,10 @@ type Connection struct {
 	AlertSourceIDs []int64     `json:"alertSourceIds"`
 	ConnectorID    string      `json:"connectorId"`
 	ConnectorType  string      `json:"connectorType"`
-	TriggerMode    string      `json:"triggerMode"`
-	TriggerTypes   []string    `json:"triggerTypes,omitempty"`
-	CreatedAt      string      `json:"createdAt,omitempty"` // date time string in ISO 8601
-	UpdatedAt      string      `json:"updatedAt,omitempty"` // date time string in ISO 8601
+	TriggerMode    string      `json:"triggerMode"`            // one of ConnectionTriggerModes
+	TriggerTypes   []string    `json:"triggerTypes,omitempty"` // incident events that trigger an automatic connection, see ConnectionTriggerTypes
+	CreatedAt      string      `json:"createdAt,omitempty"`    // date time string in ISO 8601
+	UpdatedAt      string      `json:"updatedAt,omitempty"`    // date time string in ISO 8601
 	Params         interface{} `json:"params"`
 }
 
@@ -189,6 +189,12 @@ var ConnectionTriggerModes = struct {
 	Manual:    "MANUAL",
 }
 
+// ConnectionTriggerModesAll defines all connection trigger modes
+var ConnectionTriggerModesAll = []string{
+	ConnectionTriggerModes.Automatic,
+	ConnectionTriggerModes.Manual,
+}
+
 // ConnectionTriggerTypes defines connection trigger types
 var ConnectionTriggerTypes = struct {
 	IncidentCreated       string
@@ -219,6 +225,27 @@ var ConnectionTriggerTypesAll = []string{
 	ConnectionTriggerTypes.IncidentResolved,
 }
 
+// ValidateConnection checks the trigger configuration of the connection: the trigger mode must be one of
+// ConnectionTriggerModes and trigger types, which are only evaluated for automatic connections, must be
+// ConnectionTriggerTypes
+func ValidateConnection(connection *Connection) error {
+	if connection == nil {
+		return errors.New("connection is required")
+	}
+	if !stringSliceContains(ConnectionTriggerModesAll, connection.TriggerMode) {
+		return fmt.Errorf("invalid connection trigger mode %q, must be one of %s", connection.TriggerMode, strings.Join(ConnectionTriggerModesAll, ", "))
+	}
+	for _, triggerType := range connection.TriggerTypes {
+		if !stringSliceContains(ConnectionTriggerTypesAll, triggerType) {
+			return fmt.Errorf("invalid connection trigger type %q, must be one of %s", triggerType, strings.Join(ConnectionTriggerTypesAll, ", "))
+		}
+	}
+	if connection.TriggerMode == ConnectionTriggerModes.Manual && len(connection.TriggerTypes) > 0 {
+		return fmt.Errorf("connection trigger types require trigger mode %s, manual connections are only triggered by users", ConnectionTriggerModes.Automatic)
+	}
+	return nil
+}
+
 // CreateConnectionInput represents the input of a CreateConnection operation.
 type CreateConnectionInput struct {
 	_          struct{}
@@ -239,16 +266,21 @@ func (c *Client) CreateConnection(input *CreateConnectionInput) (*CreateConnecti
 	if input.Connection == nil {
 		return nil, errors.New("Connection input is required")
 	}
-	resp, err := c.httpClient.R().SetBody(input.Connection).Post(apiRoutes.connections)
+	if c.strictMode {
+		if err := ValidateConnection(input.Connection); err != nil {
+			return nil, err
+		}
+	}
+	resp, err := c.newRequest().SetBody(input.Connection).Post(apiRoutes.connections)
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 201); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 201); apiErr != nil {
 		return nil, apiErr
 	}
 
 	connection := &ConnectionOutput{}
-	err = json.Unmarshal(resp.Body(), connection)
+	err = decodeResponse("CreateConnection", resp, connection)
 	if err != nil {
 		return nil, err
 	}
@@ -277,16 +309,16 @@ func (c *Client) GetConnection(input *GetConnectionInput) (*GetConnectionOutput,
 		return nil, errors.New("Connection id is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%s", apiRoutes.connections, *input.ConnectionID))
+	resp, err := c.newRequest().Get(fmt.Sprintf("%s/%s", apiRoutes.connections, *input.ConnectionID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	connection := &ConnectionOutput{}
-	err = json.Unmarshal(resp.Body(), connection)
+	err = decodeResponse("GetConnection", resp, connection)
 	if err != nil {
 		return nil, err
 	}
@@ -307,16 +339,16 @@ type GetConnectionsOutput struct {
 
 // GetConnections lists connections. https://api.ilert.com/api-docs/#tag/Connections/paths/~1connections/get
 func (c *Client) GetConnections(input *GetConnectionsInput) (*GetConnectionsOutput, error) {
-	resp, err := c.httpClient.R().Get(apiRoutes.connections)
+	resp, err := c.newRequest().Get(apiRoutes.connections)
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	connections := make([]*ConnectionOutput, 0)
-	err = json.Unmarshal(resp.Body(), &connections)
+	err = decodeResponse("GetConnections", resp, &connections)
 	if err != nil {
 		return nil, err
 	}
@@ -348,17 +380,22 @@ func (c *Client) UpdateConnection(input *UpdateConnectionInput) (*UpdateConnecti
 	if input.ConnectionID == nil {
 		return nil, errors.New("Connection id is required")
 	}
+	if c.strictMode {
+		if err := ValidateConnection(input.Connection); err != nil {
+			return nil, err
+		}
+	}
 
-	resp, err := c.httpClient.R().SetBody(input.Connection).Put(fmt.Sprintf("%s/%s", apiRoutes.connections, *input.ConnectionID))
+	resp, err := c.newRequest().SetBody(input.Connection).Put(fmt.Sprintf("%s/%s", apiRoutes.connections, *input.ConnectionID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	connection := &ConnectionOutput{}
-	err = json.Unmarshal(resp.Body(), connection)
+	err = decodeResponse("UpdateConnection", resp, connection)
 	if err != nil {
 		return nil, err
 	}
@@ -386,11 +423,11 @@ func (c *Client) DeleteConnection(input *DeleteConnectionInput) (*DeleteConnecti
 		return nil, errors.New("Connection id is required")
 	}
 
-	resp, err := c.httpClient.R().Delete(fmt.Sprintf("%s/%s", apiRoutes.connections, *input.ConnectionID))
+	resp, err := c.newRequest().Delete(fmt.Sprintf("%s/%s", apiRoutes.connections, *input.ConnectionID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 204); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 204); apiErr != nil {
 		return nil, apiErr
 	}
 
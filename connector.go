@@ -4,6 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
 )
 
 // Connector definition
@@ -24,6 +28,45 @@ type ConnectorOutput struct {
 	CreatedAt string                `json:"createdAt"` // date time string in ISO 8601
 	UpdatedAt string                `json:"updatedAt"` // date time string in ISO 8601
 	Params    ConnectorOutputParams `json:"params"`
+
+	// params as received from the API, see TypedParams
+	rawParams json.RawMessage
+}
+
+// UnmarshalJSON decodes the connector output and keeps the raw params for TypedParams
+func (o *ConnectorOutput) UnmarshalJSON(data []byte) error {
+	type connectorOutput ConnectorOutput
+	aux := struct {
+		*connectorOutput
+		Params json.RawMessage `json:"params"`
+	}{connectorOutput: (*connectorOutput)(o)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	o.Params = ConnectorOutputParams{}
+	o.rawParams = aux.Params
+	if len(aux.Params) == 0 || string(aux.Params) == "null" {
+		return nil
+	}
+	return json.Unmarshal(aux.Params, &o.Params)
+}
+
+// TypedParams returns the params decoded into the params struct of the connector type,
+// e.g. *ConnectorParamsJira for jira connectors, so they can be used with a type assertion.
+// For connectors received from the API the original params are decoded, including fields that
+// ConnectorOutputParams does not have, so later changes of Params are not reflected.
+// Types without params struct return map[string]interface{}, connectors without params nil
+func (o *ConnectorOutput) TypedParams() (interface{}, error) {
+	raw := o.rawParams
+	if raw == nil {
+		data, err := json.Marshal(o.Params)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s connector params: %w", o.Type, err)
+		}
+		raw = data
+	}
+	return decodeConnectorParams(o.Type, raw)
 }
 
 // ConnectorOutputParams definition
@@ -130,6 +173,58 @@ type ConnectorParamsStatusPageIO struct {
 	APIKey string `json:"apiKey"`
 }
 
+// ConnectorParamsWebhook definition
+type ConnectorParamsWebhook struct {
+	URL     string                `json:"url"`
+	Method  string                `json:"method,omitempty"`  // one of ConnectorWebhookMethods, defaults to POST
+	Headers map[string]string     `json:"headers,omitempty"` // additional request headers
+	Auth    *ConnectorWebhookAuth `json:"auth,omitempty"`
+}
+
+// ConnectorWebhookAuth definition
+type ConnectorWebhookAuth struct {
+	Type     string `json:"type"`               // one of ConnectorWebhookAuthTypes
+	Username string `json:"username,omitempty"` // basic auth
+	Password string `json:"password,omitempty"` // basic auth
+	Token    string `json:"token,omitempty"`    // bearer auth
+}
+
+// ConnectorWebhookMethods defines webhook connector request methods
+var ConnectorWebhookMethods = struct {
+	Get  string
+	Post string
+	Put  string
+}{
+	Get:  "GET",
+	Post: "POST",
+	Put:  "PUT",
+}
+
+// ConnectorWebhookMethodsAll defines webhook connector request methods list
+var ConnectorWebhookMethodsAll = []string{
+	ConnectorWebhookMethods.Get,
+	ConnectorWebhookMethods.Post,
+	ConnectorWebhookMethods.Put,
+}
+
+// ConnectorWebhookAuthTypes defines webhook connector auth types
+var ConnectorWebhookAuthTypes = struct {
+	None   string
+	Basic  string
+	Bearer string
+}{
+	None:   "NONE",
+	Basic:  "BASIC",
+	Bearer: "BEARER",
+}
+
+// ConnectorWebhookAuthTypesAll defines webhook connector auth types list
+var ConnectorWebhookAuthTypesAll = []string{
+	ConnectorWebhookAuthTypes.None,
+	ConnectorWebhookAuthTypes.Basic,
+	ConnectorWebhookAuthTypes.Bearer,
+}
+
 // ConnectorTypes defines connector types
 var ConnectorTypes = struct {
 	AWSLambda             string
@@ -214,6 +309,175 @@ var ConnectorTypesAll = []string{
 	ConnectorTypes.Webex,
 }
 
+// connectorRequiredParams defines the params that must be non-empty for each connector type
+var connectorRequiredParams = map[string][]string{
+	ConnectorTypes.Datadog:        {"apiKey"},
+	ConnectorTypes.Jira:           {"url", "email", "password"},
+	ConnectorTypes.MicrosoftTeams: {"url"},
+	ConnectorTypes.ServiceNow:     {"url", "username", "password"},
+	ConnectorTypes.Zendesk:        {"url", "email", "apiKey"},
+	ConnectorTypes.Discord:        {"url"},
+	ConnectorTypes.Github:         {"apiKey"},
+	ConnectorTypes.Topdesk:        {"url", "username", "password"},
+	ConnectorTypes.Sysdig:         {"apiKey"},
+	ConnectorTypes.Autotask:       {"url", "email", "password"},
+	ConnectorTypes.Mattermost:     {"url"},
+	ConnectorTypes.Zammad:         {"url", "apiKey"},
+	ConnectorTypes.StatusPageIO:   {"apiKey"},
+	ConnectorTypes.Webhook:        {"url"},
+}
+
+// ValidateConnector checks that the params of the connector contain all fields required by its type,
+// e.g. "jira connector requires non-empty url, email, password". Webhook connectors are also checked for a valid
+// method and complete auth settings
+func ValidateConnector(connector *Connector) error {
+	if connector == nil {
+		return errors.New("connector is required")
+	}
+	if connector.Type == "" {
+		return errors.New("connector type is required")
+	}
+
+	required, ok := connectorRequiredParams[connector.Type]
+	if !ok {
+		return nil
+	}
+
+	params := map[string]interface{}{}
+	if connector.Params != nil {
+		data, err := json.Marshal(connector.Params)
+		if err != nil {
+			return fmt.Errorf("invalid %s connector params: %w", connector.Type, err)
+		}
+		err = json.Unmarshal(data, &params)
+		if err != nil {
+			return fmt.Errorf("invalid %s connector params: %w", connector.Type, err)
+		}
+	}
+
+	for _, field := range required {
+		if value, ok := params[field].(string); !ok || value == "" {
+			return fmt.Errorf("%s connector requires non-empty %s", connector.Type, strings.Join(required, ", "))
+		}
+	}
+
+	if connector.Type == ConnectorTypes.Webhook {
+		webhook := &ConnectorParamsWebhook{}
+		if err := decodeInto(params, webhook); err != nil {
+			return fmt.Errorf("invalid %s connector params: %w", connector.Type, err)
+		}
+		return validateWebhookParams(webhook)
+	}
+
+	return nil
+}
+
+// decodeInto converts generic params into a params struct
+func decodeInto(params map[string]interface{}, out interface{}) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+func validateWebhookParams(params *ConnectorParamsWebhook) error {
+	if params.Method != "" && !stringSliceContains(ConnectorWebhookMethodsAll, params.Method) {
+		return fmt.Errorf("invalid webhook connector method %q, must be one of %s", params.Method, strings.Join(ConnectorWebhookMethodsAll, ", "))
+	}
+	if params.Auth == nil {
+		return nil
+	}
+	switch params.Auth.Type {
+	case ConnectorWebhookAuthTypes.None:
+	case ConnectorWebhookAuthTypes.Basic:
+		if params.Auth.Username == "" || params.Auth.Password == "" {
+			return errors.New("webhook connector basic auth requires non-empty username, password")
+		}
+	case ConnectorWebhookAuthTypes.Bearer:
+		if params.Auth.Token == "" {
+			return errors.New("webhook connector bearer auth requires non-empty token")
+		}
+	default:
+		return fmt.Errorf("invalid webhook connector auth type %q, must be one of %s", params.Auth.Type, strings.Join(ConnectorWebhookAuthTypesAll, ", "))
+	}
+	return nil
+}
+
+// NormalizeConnectorParams normalizes the url param of the connector in place, if it has one:
+// surrounding whitespace and trailing slashes are removed and a missing scheme defaults to https,
+// e.g. "example.atlassian.net/" becomes "https://example.atlassian.net".
+// An error is returned if the url can not be parsed or has no host
+func NormalizeConnectorParams(connector *Connector) error {
+	if connector == nil {
+		return errors.New("connector is required")
+	}
+	if connector.Params == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(connector.Params)
+	if err != nil {
+		return fmt.Errorf("invalid %s connector params: %w", connector.Type, err)
+	}
+	params := map[string]interface{}{}
+	if err := json.Unmarshal(data, &params); err != nil {
+		return fmt.Errorf("invalid %s connector params: %w", connector.Type, err)
+	}
+
+	raw, ok := params["url"].(string)
+	if !ok || raw == "" {
+		return nil
+	}
+	normalized, err := normalizeConnectorURL(raw)
+	if err != nil {
+		return fmt.Errorf("invalid %s connector url %q: %w", connector.Type, raw, err)
+	}
+	if normalized == raw {
+		return nil
+	}
+	params["url"] = normalized
+
+	// write the params back using their original type
+	data, err = json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("invalid %s connector params: %w", connector.Type, err)
+	}
+	paramsType := reflect.TypeOf(connector.Params)
+	isPtr := paramsType.Kind() == reflect.Ptr
+	if isPtr {
+		paramsType = paramsType.Elem()
+	}
+	value := reflect.New(paramsType)
+	if err := json.Unmarshal(data, value.Interface()); err != nil {
+		return fmt.Errorf("invalid %s connector params: %w", connector.Type, err)
+	}
+	if isPtr {
+		connector.Params = value.Interface()
+	} else {
+		connector.Params = value.Elem().Interface()
+	}
+
+	return nil
+}
+
+func normalizeConnectorURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	if u.Host == "" {
+		return "", errors.New("host is missing")
+	}
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = ""
+	return u.String(), nil
+}
+
 // CreateConnectorInput represents the input of a CreateConnector operation.
 type CreateConnectorInput struct {
 	_         struct{}
@@ -222,7 +486,10 @@ type CreateConnectorInput struct {
 
 // CreateConnectorOutput represents the output of a CreateConnector operation.
 type CreateConnectorOutput struct {
-	_         struct{}
+	_ struct{}
+
+	// the created connector, its ID is generated by the server and always populated.
+	// Use it (not the ID of the input connector) for subsequent GetConnector, UpdateConnector or DeleteConnector calls
 	Connector *ConnectorOutput
 }
 
@@ -234,23 +501,67 @@ func (c *Client) CreateConnector(input *CreateConnectorInput) (*CreateConnectorO
 	if input.Connector == nil {
 		return nil, errors.New("Connector input is required")
 	}
-	resp, err := c.httpClient.R().SetBody(input.Connector).Post(apiRoutes.connectors)
+	if err := validateConnectorParamsType(input.Connector); err != nil {
+		return nil, err
+	}
+	if c.strictMode {
+		if err := NormalizeConnectorParams(input.Connector); err != nil {
+			return nil, err
+		}
+		if err := ValidateConnector(input.Connector); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.newRequest().SetBody(input.Connector).Post(apiRoutes.connectors)
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 201); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 201); apiErr != nil {
 		return nil, apiErr
 	}
 
 	connector := &ConnectorOutput{}
-	err = json.Unmarshal(resp.Body(), connector)
+	err = decodeResponse("CreateConnector", resp, connector)
 	if err != nil {
 		return nil, err
 	}
 
+	if connector.ID == "" {
+		connector.ID, err = c.findCreatedConnectorID(input.Connector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &CreateConnectorOutput{Connector: connector}, nil
 }
 
+// findCreatedConnectorID looks up the id of a created connector by name and type,
+// for create responses that do not contain the id
+func (c *Client) findCreatedConnectorID(connector *Connector) (string, error) {
+	output, err := c.GetConnectors(&GetConnectorsInput{})
+	if err != nil {
+		return "", err
+	}
+
+	id := ""
+	for _, existing := range output.Connectors {
+		if existing.Name != connector.Name || existing.Type != connector.Type {
+			continue
+		}
+		if id != "" {
+			return "", fmt.Errorf("connector %q was created but its id could not be determined: %w", connector.Name, ErrMultipleMatches)
+		}
+		id = existing.ID
+	}
+	if id == "" {
+		return "", fmt.Errorf("connector %q was created but its id could not be determined: %w", connector.Name, ErrNotFound)
+	}
+
+	return id, nil
+}
+
 // GetConnectorInput represents the input of a GetConnector operation.
 type GetConnectorInput struct {
 	_           struct{}
@@ -272,16 +583,16 @@ func (c *Client) GetConnector(input *GetConnectorInput) (*GetConnectorOutput, er
 		return nil, errors.New("Connector id is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%s", apiRoutes.connectors, *input.ConnectorID))
+	resp, err := c.newRequest().Get(fmt.Sprintf("%s/%s", apiRoutes.connectors, *input.ConnectorID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	connector := &ConnectorOutput{}
-	err = json.Unmarshal(resp.Body(), connector)
+	err = decodeResponse("GetConnector", resp, connector)
 	if err != nil {
 		return nil, err
 	}
@@ -289,9 +600,37 @@ func (c *Client) GetConnector(input *GetConnectorInput) (*GetConnectorOutput, er
 	return &GetConnectorOutput{Connector: connector}, nil
 }
 
+// ConnectorExists checks if the connector with specified id exists
+func (c *Client) ConnectorExists(id string) (bool, error) {
+	_, err := c.GetConnector(&GetConnectorInput{ConnectorID: String(id)})
+	if err != nil {
+		if IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ConnectorSortFields defines the fields connectors can be sorted by
+var ConnectorSortFields = struct {
+	Name      string
+	Type      string
+	CreatedAt string
+}{
+	Name:      "name",
+	Type:      "type",
+	CreatedAt: "createdAt",
+}
+
 // GetConnectorsInput represents the input of a GetConnectors operation.
 type GetConnectorsInput struct {
 	_ struct{}
+
+	// (optional) sort the connectors client side by one of ConnectorSortFields, ties are ordered by id.
+	// Default: server order
+	SortBy *string
 }
 
 // GetConnectorsOutput represents the output of a GetConnectors operation.
@@ -302,23 +641,53 @@ type GetConnectorsOutput struct {
 
 // GetConnectors lists connectors. https://api.ilert.com/api-docs/#tag/Connectors/paths/~1connectors/get
 func (c *Client) GetConnectors(input *GetConnectorsInput) (*GetConnectorsOutput, error) {
-	resp, err := c.httpClient.R().Get(apiRoutes.connectors)
+	if input == nil {
+		input = &GetConnectorsInput{}
+	}
+	if input.SortBy != nil && *input.SortBy != ConnectorSortFields.Name && *input.SortBy != ConnectorSortFields.Type && *input.SortBy != ConnectorSortFields.CreatedAt {
+		return nil, fmt.Errorf("invalid connector sort field %q", *input.SortBy)
+	}
+
+	resp, err := c.newRequest().Get(apiRoutes.connectors)
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	connectors := make([]*ConnectorOutput, 0)
-	err = json.Unmarshal(resp.Body(), &connectors)
+	err = decodeResponse("GetConnectors", resp, &connectors)
 	if err != nil {
 		return nil, err
 	}
 
+	if input.SortBy != nil {
+		sortConnectors(connectors, *input.SortBy)
+	}
+
 	return &GetConnectorsOutput{Connectors: connectors}, nil
 }
 
+func sortConnectors(connectors []*ConnectorOutput, sortBy string) {
+	key := func(connector *ConnectorOutput) string {
+		switch sortBy {
+		case ConnectorSortFields.Type:
+			return connector.Type
+		case ConnectorSortFields.CreatedAt:
+			return connector.CreatedAt
+		}
+		return connector.Name
+	}
+	sort.SliceStable(connectors, func(i, j int) bool {
+		ki, kj := key(connectors[i]), key(connectors[j])
+		if ki != kj {
+			return ki < kj
+		}
+		return connectors[i].ID < connectors[j].ID
+	})
+}
+
 // UpdateConnectorInput represents the input of a UpdateConnector operation.
 type UpdateConnectorInput struct {
 	_           struct{}
@@ -343,17 +712,28 @@ func (c *Client) UpdateConnector(input *UpdateConnectorInput) (*UpdateConnectorO
 	if input.ConnectorID == nil {
 		return nil, errors.New("Connector id is required")
 	}
+	if err := validateConnectorParamsType(input.Connector); err != nil {
+		return nil, err
+	}
+	if c.strictMode {
+		if err := NormalizeConnectorParams(input.Connector); err != nil {
+			return nil, err
+		}
+		if err := ValidateConnector(input.Connector); err != nil {
+			return nil, err
+		}
+	}
 
-	resp, err := c.httpClient.R().SetBody(input.Connector).Put(fmt.Sprintf("%s/%s", apiRoutes.connectors, *input.ConnectorID))
+	resp, err := c.newRequest().SetBody(input.Connector).Put(fmt.Sprintf("%s/%s", apiRoutes.connectors, *input.ConnectorID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	connector := &ConnectorOutput{}
-	err = json.Unmarshal(resp.Body(), connector)
+	err = decodeResponse("UpdateConnector", resp, connector)
 	if err != nil {
 		return nil, err
 	}
@@ -361,6 +741,117 @@ func (c *Client) UpdateConnector(input *UpdateConnectorInput) (*UpdateConnectorO
 	return &UpdateConnectorOutput{Connector: connector}, nil
 }
 
+// connectorSecretParams defines the params field holding the secret of each connector type
+var connectorSecretParams = map[string]string{
+	ConnectorTypes.Datadog:      "apiKey",
+	ConnectorTypes.Zendesk:      "apiKey",
+	ConnectorTypes.Github:       "apiKey",
+	ConnectorTypes.Sysdig:       "apiKey",
+	ConnectorTypes.Zammad:       "apiKey",
+	ConnectorTypes.StatusPageIO: "apiKey",
+	ConnectorTypes.Jira:         "password",
+	ConnectorTypes.ServiceNow:   "password",
+	ConnectorTypes.Topdesk:      "password",
+	ConnectorTypes.Autotask:     "password",
+	ConnectorTypes.AWSLambda:    "authorization",
+	ConnectorTypes.AzureFAAS:    "authorization",
+	ConnectorTypes.GoogleFAAS:   "authorization",
+}
+
+// UpdateConnectorSecret replaces the secret (api key, password or authorization header, depending on the connector type)
+// of an existing connector, keeping all other connector settings.
+func (c *Client) UpdateConnectorSecret(connectorID string, secret string) error {
+	if connectorID == "" {
+		return errors.New("Connector id is required")
+	}
+	if secret == "" {
+		return errors.New("secret is required")
+	}
+
+	output, err := c.GetConnector(&GetConnectorInput{ConnectorID: String(connectorID)})
+	if err != nil {
+		return err
+	}
+	connector := output.Connector
+
+	params := connector.Params
+	switch connectorSecretParams[connector.Type] {
+	case "apiKey":
+		params.APIKey = secret
+	case "password":
+		params.Password = secret
+	case "authorization":
+		params.Authorization = secret
+	default:
+		return fmt.Errorf("connector type %s has no secret", connector.Type)
+	}
+
+	_, err = c.UpdateConnector(&UpdateConnectorInput{
+		ConnectorID: String(connectorID),
+		Connector: &Connector{
+			Name:   connector.Name,
+			Type:   connector.Type,
+			Params: params,
+		},
+	})
+	return err
+}
+
+// ConnectorMultipleMatchesError is returned by UpsertConnector when more than one connector has the name
+type ConnectorMultipleMatchesError struct {
+	Name         string
+	ConnectorIDs []string
+}
+
+func (e *ConnectorMultipleMatchesError) Error() string {
+	return fmt.Sprintf("connector %q: %s: %s", e.Name, ErrMultipleMatches, strings.Join(e.ConnectorIDs, ", "))
+}
+
+// Unwrap allows errors.Is(err, ErrMultipleMatches)
+func (e *ConnectorMultipleMatchesError) Unwrap() error {
+	return ErrMultipleMatches
+}
+
+// UpsertConnector creates the connector if no connector with its name exists, otherwise the existing connector is updated.
+// Returns a *ConnectorMultipleMatchesError if more than one connector has the name
+func (c *Client) UpsertConnector(connector *Connector) (*ConnectorOutput, error) {
+	if connector == nil {
+		return nil, errors.New("Connector input is required")
+	}
+	if connector.Name == "" {
+		return nil, errors.New("connector name is required")
+	}
+
+	output, err := c.GetConnectors(&GetConnectorsInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]string, 0)
+	for _, existing := range output.Connectors {
+		if existing.Name == connector.Name {
+			matches = append(matches, existing.ID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		createOutput, err := c.CreateConnector(&CreateConnectorInput{Connector: connector})
+		if err != nil {
+			return nil, err
+		}
+		return createOutput.Connector, nil
+	case 1:
+		updateOutput, err := c.UpdateConnector(&UpdateConnectorInput{ConnectorID: String(matches[0]), Connector: connector})
+		if err != nil {
+			return nil, err
+		}
+		return updateOutput.Connector, nil
+	default:
+		return nil, &ConnectorMultipleMatchesError{Name: connector.Name, ConnectorIDs: matches}
+	}
+}
+
 // DeleteConnectorInput represents the input of a DeleteConnector operation.
 type DeleteConnectorInput struct {
 	_           struct{}
@@ -381,13 +872,169 @@ func (c *Client) DeleteConnector(input *DeleteConnectorInput) (*DeleteConnectorO
 		return nil, errors.New("Connector id is required")
 	}
 
-	resp, err := c.httpClient.R().Delete(fmt.Sprintf("%s/%s", apiRoutes.connectors, *input.ConnectorID))
+	resp, err := c.newRequest().Delete(fmt.Sprintf("%s/%s", apiRoutes.connectors, *input.ConnectorID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 204); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 204); apiErr != nil {
 		return nil, apiErr
 	}
 
 	return &DeleteConnectorOutput{}, nil
 }
+
+// ConnectorReference describes a connection that uses a connector, including the alert sources of the connection
+type ConnectorReference struct {
+	ConnectionID   string
+	ConnectionName string
+	AlertSourceIDs []int64
+}
+
+// GetConnectorReferences lists the connections (and their alert sources) that use the specified connector.
+// The API has no reverse lookup, so all connections are listed and filtered client side.
+func (c *Client) GetConnectorReferences(connectorID string) ([]ConnectorReference, error) {
+	if connectorID == "" {
+		return nil, errors.New("Connector id is required")
+	}
+
+	output, err := c.GetConnections(&GetConnectionsInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	references := make([]ConnectorReference, 0)
+	for _, connection := range output.Connections {
+		if connection.ConnectorID != connectorID {
+			continue
+		}
+		references = append(references, ConnectorReference{
+			ConnectionID:   connection.ID,
+			ConnectionName: connection.Name,
+			AlertSourceIDs: connection.AlertSourceIDs,
+		})
+	}
+
+	return references, nil
+}
+
+// ConnectorTyped definition, a connector with params decoded into the params struct of its type
+type ConnectorTyped struct {
+	ID        string
+	Name      string
+	Type      string
+	CreatedAt string // date time string in ISO 8601
+	UpdatedAt string // date time string in ISO 8601
+
+	// e.g. *ConnectorParamsJira for jira connectors, map[string]interface{} for types without params struct,
+	// nil if the connector has no params
+	Params interface{}
+}
+
+// GetConnectorsTyped lists connectors like GetConnectors with params decoded into the params struct of their type,
+// see ConnectorOutput.TypedParams
+func (c *Client) GetConnectorsTyped() ([]ConnectorTyped, error) {
+	output, err := c.GetConnectors(&GetConnectorsInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	connectors := make([]ConnectorTyped, 0, len(output.Connectors))
+	for _, connector := range output.Connectors {
+		params, err := connector.TypedParams()
+		if err != nil {
+			return nil, fmt.Errorf("connector %s: %w", connector.ID, err)
+		}
+		connectors = append(connectors, ConnectorTyped{
+			ID:        connector.ID,
+			Name:      connector.Name,
+			Type:      connector.Type,
+			CreatedAt: connector.CreatedAt,
+			UpdatedAt: connector.UpdatedAt,
+			Params:    params,
+		})
+	}
+
+	return connectors, nil
+}
+
+// connectorParamsTypes defines the params struct of each connector type
+var connectorParamsTypes = map[string]reflect.Type{
+	ConnectorTypes.AWSLambda:      reflect.TypeOf(ConnectorParamsAWSLambda{}),
+	ConnectorTypes.AzureFAAS:      reflect.TypeOf(ConnectorParamsAzureFunction{}),
+	ConnectorTypes.Datadog:        reflect.TypeOf(ConnectorParamsDatadog{}),
+	ConnectorTypes.Discord:        reflect.TypeOf(ConnectorParamsDiscord{}),
+	ConnectorTypes.Github:         reflect.TypeOf(ConnectorParamsGithub{}),
+	ConnectorTypes.GoogleFAAS:     reflect.TypeOf(ConnectorParamsGoogleFunction{}),
+	ConnectorTypes.Jira:           reflect.TypeOf(ConnectorParamsJira{}),
+	ConnectorTypes.MicrosoftTeams: reflect.TypeOf(ConnectorParamsMicrosoftTeams{}),
+	ConnectorTypes.ServiceNow:     reflect.TypeOf(ConnectorParamsServiceNow{}),
+	ConnectorTypes.Slack:          reflect.TypeOf(ConnectorParamsSlack{}),
+	ConnectorTypes.Sysdig:         reflect.TypeOf(ConnectorParamsSysdig{}),
+	ConnectorTypes.Topdesk:        reflect.TypeOf(ConnectorParamsTopdesk{}),
+	ConnectorTypes.Zendesk:        reflect.TypeOf(ConnectorParamsZendesk{}),
+	ConnectorTypes.Autotask:       reflect.TypeOf(ConnectorParamsAutotask{}),
+	ConnectorTypes.Mattermost:     reflect.TypeOf(ConnectorParamsMattermost{}),
+	ConnectorTypes.Zammad:         reflect.TypeOf(ConnectorParamsZammad{}),
+	ConnectorTypes.StatusPageIO:   reflect.TypeOf(ConnectorParamsStatusPageIO{}),
+	ConnectorTypes.Webhook:        reflect.TypeOf(ConnectorParamsWebhook{}),
+}
+
+// newConnectorParams returns a pointer to the params struct of the connector type, nil for types without params struct
+func newConnectorParams(connectorType string) interface{} {
+	paramsType, ok := connectorParamsTypes[connectorType]
+	if !ok {
+		return nil
+	}
+	return reflect.New(paramsType).Interface()
+}
+
+// validateConnectorParamsType checks that params given as one of the ConnectorParams structs, by value or pointer,
+// is the params struct of the connector type, e.g. "params type *ConnectorParamsDatadog does not match connector
+// type jira". Other params like maps or ConnectorOutputParams are not checked
+func validateConnectorParamsType(connector *Connector) error {
+	if connector.Params == nil {
+		return nil
+	}
+
+	paramsType := reflect.TypeOf(connector.Params)
+	name := ""
+	if paramsType.Kind() == reflect.Ptr {
+		paramsType = paramsType.Elem()
+		name = "*"
+	}
+	name += paramsType.Name()
+
+	known := false
+	for _, t := range connectorParamsTypes {
+		if t == paramsType {
+			known = true
+			break
+		}
+	}
+	if !known || connectorParamsTypes[connector.Type] == paramsType {
+		return nil
+	}
+
+	return fmt.Errorf("params type %s does not match connector type %s", name, connector.Type)
+}
+
+// decodeConnectorParams decodes raw connector params into the params struct of the connector type
+func decodeConnectorParams(connectorType string, raw json.RawMessage) (interface{}, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	params := newConnectorParams(connectorType)
+	if params == nil {
+		generic := map[string]interface{}{}
+		if err := json.Unmarshal(raw, &generic); err != nil {
+			return nil, fmt.Errorf("invalid %s connector params: %w", connectorType, err)
+		}
+		return generic, nil
+	}
+	if err := json.Unmarshal(raw, params); err != nil {
+		return nil, fmt.Errorf("invalid %s connector params: %w", connectorType, err)
+	}
+
+	return params, nil
+}
@@ -1,16 +1,21 @@
 package ilert
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // EscalationPolicy definition https://api.ilert.com/api-docs/#!/Escalation_Policies
 type EscalationPolicy struct {
 	ID              int64            `json:"id,omitempty"`
-	Name            string           `json:"name"`
-	EscalationRules []EscalationRule `json:"escalationRules"`
+	Name            string           `json:"name,omitempty"`
+	EscalationRules []EscalationRule `json:"escalationRules,omitempty"` // omitted when empty, so partial updates do not send null rules
 	Repeating       bool             `json:"repeating,omitempty"`
 	Frequency       int              `json:"frequency,omitempty"`
 	Teams           []TeamShort      `json:"teams,omitempty"`
@@ -18,9 +23,108 @@ type EscalationPolicy struct {
 
 // EscalationRule definition
 type EscalationRule struct {
-	User              *User     `json:"user,omitempty"`
-	Schedule          *Schedule `json:"schedule,omitempty"`
-	EscalationTimeout int       `json:"escalationTimeout"`
+	User              *User       `json:"user,omitempty"`
+	Schedule          *Schedule   `json:"schedule,omitempty"`
+	Users             []*User     `json:"users,omitempty"`     // notify multiple users at the same escalation step
+	Schedules         []*Schedule `json:"schedules,omitempty"` // notify the on-call users of multiple schedules at the same escalation step
+	EscalationTimeout int         `json:"escalationTimeout"`   // in minutes, see EscalationTimeoutUnit and SetEscalationTimeout
+}
+
+// EscalationTimeoutUnit is the unit of EscalationRule.EscalationTimeout
+const EscalationTimeoutUnit = time.Minute
+
+// EscalationTimeoutMinutes checks that minutes is a valid escalation timeout and returns it.
+// Only negative values are rejected, the API documents no upper bound
+func EscalationTimeoutMinutes(minutes int) (int, error) {
+	if minutes < 0 {
+		return 0, fmt.Errorf("escalation timeout must not be negative, got %d minutes", minutes)
+	}
+	return minutes, nil
+}
+
+// SetEscalationTimeout sets the escalation timeout of the rule from a duration, which must be a whole number of minutes
+func (r *EscalationRule) SetEscalationTimeout(d time.Duration) error {
+	if d%EscalationTimeoutUnit != 0 {
+		return fmt.Errorf("escalation timeout must be a whole number of minutes, got %s", d)
+	}
+	minutes, err := EscalationTimeoutMinutes(int(d / EscalationTimeoutUnit))
+	if err != nil {
+		return err
+	}
+	r.EscalationTimeout = minutes
+	return nil
+}
+
+// EscalationTimeoutDuration returns the escalation timeout of the rule as duration
+func (r EscalationRule) EscalationTimeoutDuration() time.Duration {
+	return time.Duration(r.EscalationTimeout) * EscalationTimeoutUnit
+}
+
+// PreviewStep is an escalation rule notified at a concrete time, see EscalationPolicy.PreviewCycles
+type PreviewStep struct {
+	Cycle     int // pass through the escalation rules, 0 is the initial pass, 1 the first repeat
+	RuleIndex int
+	Rule      EscalationRule
+	Time      time.Time
+}
+
+// PreviewCycles expands the escalation rules into the times they are notified when an incident is created at base
+// and nobody responds. A repeating policy passes through its rules 1 + Frequency times, a policy that is not
+// repeating or has Frequency 0 passes once. At most cycles passes are previewed
+func (e *EscalationPolicy) PreviewCycles(base time.Time, cycles int) ([]PreviewStep, error) {
+	if cycles <= 0 {
+		return nil, errors.New("cycles must be positive")
+	}
+	if len(e.EscalationRules) == 0 {
+		return nil, errors.New("escalation policy has no escalation rules")
+	}
+
+	passes := 1
+	if e.Repeating && e.Frequency > 0 {
+		passes += e.Frequency
+	}
+	if cycles < passes {
+		passes = cycles
+	}
+
+	steps := make([]PreviewStep, 0, passes*len(e.EscalationRules))
+	t := base
+	for cycle := 0; cycle < passes; cycle++ {
+		for i, rule := range e.EscalationRules {
+			steps = append(steps, PreviewStep{
+				Cycle:     cycle,
+				RuleIndex: i,
+				Rule:      rule,
+				Time:      t,
+			})
+			t = t.Add(rule.EscalationTimeoutDuration())
+		}
+	}
+
+	return steps, nil
+}
+
+// validateEscalationPolicy checks that every escalation rule has at least one target
+func validateEscalationPolicy(escalationPolicy *EscalationPolicy) error {
+	for i, rule := range escalationPolicy.EscalationRules {
+		if rule.User == nil && rule.Schedule == nil && len(rule.Users) == 0 && len(rule.Schedules) == 0 {
+			return fmt.Errorf("escalation rule %d requires a user, schedule, users or schedules", i)
+		}
+		for _, user := range rule.Users {
+			if user == nil {
+				return fmt.Errorf("escalation rule %d contains a nil user", i)
+			}
+		}
+		for _, schedule := range rule.Schedules {
+			if schedule == nil {
+				return fmt.Errorf("escalation rule %d contains a nil schedule", i)
+			}
+		}
+		if _, err := EscalationTimeoutMinutes(rule.EscalationTimeout); err != nil {
+			return fmt.Errorf("escalation rule %d: %w", i, err)
+		}
+	}
+	return nil
 }
 
 // CreateEscalationPolicyInput represents the input of a CreateEscalationPolicy operation.
@@ -43,16 +147,19 @@ func (c *Client) CreateEscalationPolicy(input *CreateEscalationPolicyInput) (*Cr
 	if input.EscalationPolicy == nil {
 		return nil, errors.New("escalation policy input is required")
 	}
-	resp, err := c.httpClient.R().SetBody(input.EscalationPolicy).Post(apiRoutes.escalationPolicies)
+	if err := validateEscalationPolicy(input.EscalationPolicy); err != nil {
+		return nil, err
+	}
+	resp, err := c.newRequest().SetBody(input.EscalationPolicy).Post(apiRoutes.escalationPolicies)
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 201); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 201); apiErr != nil {
 		return nil, apiErr
 	}
 
 	escalationPolicy := &EscalationPolicy{}
-	err = json.Unmarshal(resp.Body(), escalationPolicy)
+	err = decodeResponse("CreateEscalationPolicy", resp, escalationPolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -81,16 +188,16 @@ func (c *Client) GetEscalationPolicy(input *GetEscalationPolicyInput) (*GetEscal
 		return nil, errors.New("EscalationPolicy id is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d", apiRoutes.escalationPolicies, *input.EscalationPolicyID))
+	resp, err := c.newRequest().Get(fmt.Sprintf("%s/%d", apiRoutes.escalationPolicies, *input.EscalationPolicyID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	escalationPolicy := &EscalationPolicy{}
-	err = json.Unmarshal(resp.Body(), escalationPolicy)
+	err = decodeResponse("GetEscalationPolicy", resp, escalationPolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -98,9 +205,80 @@ func (c *Client) GetEscalationPolicy(input *GetEscalationPolicyInput) (*GetEscal
 	return &GetEscalationPolicyOutput{EscalationPolicy: escalationPolicy}, nil
 }
 
+// GetEscalationPoliciesUsingSchedule lists the escalation policies with a rule that notifies the schedule,
+// e.g. before the schedule is deleted. The API can not filter by rule targets, so all escalation policies of all
+// teams are fetched (ignoring WithDefaultTeam) and filtered client side
+func (c *Client) GetEscalationPoliciesUsingSchedule(scheduleID int64) ([]*EscalationPolicy, error) {
+	return c.getEscalationPoliciesWithRule(func(rule EscalationRule) bool {
+		if rule.Schedule != nil && rule.Schedule.ID == scheduleID {
+			return true
+		}
+		for _, schedule := range rule.Schedules {
+			if schedule != nil && schedule.ID == scheduleID {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// GetEscalationPoliciesUsingUser lists the escalation policies with a rule that notifies the user directly,
+// e.g. before the user is deactivated. Schedules the user is on call for are not resolved.
+// The API can not filter by rule targets, so all escalation policies of all teams are fetched
+// (ignoring WithDefaultTeam) and filtered client side
+func (c *Client) GetEscalationPoliciesUsingUser(userID int64) ([]*EscalationPolicy, error) {
+	return c.getEscalationPoliciesWithRule(func(rule EscalationRule) bool {
+		if rule.User != nil && rule.User.ID == userID {
+			return true
+		}
+		for _, user := range rule.Users {
+			if user != nil && user.ID == userID {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// getEscalationPoliciesWithRule lists the escalation policies of all teams with at least one rule matching fn
+func (c *Client) getEscalationPoliciesWithRule(fn func(rule EscalationRule) bool) ([]*EscalationPolicy, error) {
+	output, err := c.GetEscalationPolicies(&GetEscalationPoliciesInput{TeamIDs: []*int64{}})
+	if err != nil {
+		return nil, err
+	}
+
+	escalationPolicies := make([]*EscalationPolicy, 0)
+	for _, escalationPolicy := range output.EscalationPolicies {
+		for _, rule := range escalationPolicy.EscalationRules {
+			if fn(rule) {
+				escalationPolicies = append(escalationPolicies, escalationPolicy)
+				break
+			}
+		}
+	}
+
+	return escalationPolicies, nil
+}
+
+// EscalationPolicyExists checks if the escalation policy with specified id exists
+func (c *Client) EscalationPolicyExists(id int64) (bool, error) {
+	_, err := c.GetEscalationPolicy(&GetEscalationPolicyInput{EscalationPolicyID: Int64(id)})
+	if err != nil {
+		if IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
 // GetEscalationPoliciesInput represents the input of a GetEscalationPolicies operation.
 type GetEscalationPoliciesInput struct {
 	_ struct{}
+
+	// IDs of the teams the escalation policies belong to, see WithDefaultTeam
+	TeamIDs []*int64
 }
 
 // GetEscalationPoliciesOutput represents the output of a GetEscalationPolicies operation.
@@ -111,16 +289,28 @@ type GetEscalationPoliciesOutput struct {
 
 // GetEscalationPolicies lists escalation policies. https://api.ilert.com/api-docs/#tag/Escalation-Policies/paths/~1escalation-policies/get
 func (c *Client) GetEscalationPolicies(input *GetEscalationPoliciesInput) (*GetEscalationPoliciesOutput, error) {
-	resp, err := c.httpClient.R().Get(apiRoutes.escalationPolicies)
+	if input == nil {
+		input = &GetEscalationPoliciesInput{}
+	}
+
+	q := url.Values{}
+	for _, teamID := range c.teamIDsOrDefault(input.TeamIDs) {
+		if teamID == nil || *teamID <= 0 {
+			return nil, errors.New("team ids must be positive")
+		}
+		q.Add("team", strconv.FormatInt(*teamID, 10))
+	}
+
+	resp, err := c.newRequest().Get(fmt.Sprintf("%s?%s", apiRoutes.escalationPolicies, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	escalationPolicies := make([]*EscalationPolicy, 0)
-	err = json.Unmarshal(resp.Body(), &escalationPolicies)
+	err = decodeResponse("GetEscalationPolicies", resp, &escalationPolicies)
 	if err != nil {
 		return nil, err
 	}
@@ -128,11 +318,69 @@ func (c *Client) GetEscalationPolicies(input *GetEscalationPoliciesInput) (*GetE
 	return &GetEscalationPoliciesOutput{EscalationPolicies: escalationPolicies}, nil
 }
 
+// ResolveEscalationPolicyTeams fills in the names of the teams of the given escalation policies.
+// The API may only return team ids, so every distinct team without a name is fetched once using GetTeam,
+// with at most 5 requests in parallel.
+func (c *Client) ResolveEscalationPolicyTeams(escalationPolicies []*EscalationPolicy) error {
+	teamIDs := make([]int64, 0)
+	teamNames := make(map[int64]string)
+	for _, escalationPolicy := range escalationPolicies {
+		for _, team := range escalationPolicy.Teams {
+			if _, ok := teamNames[team.ID]; team.Name == "" && !ok {
+				teamIDs = append(teamIDs, team.ID)
+				teamNames[team.ID] = ""
+			}
+		}
+	}
+	if len(teamIDs) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(teamIDs))
+	err := batchExecute(context.Background(), len(teamIDs), defaultBulkConcurrency, true, func(ctx context.Context, i int) error {
+		output, err := c.withContext(ctx).GetTeam(&GetTeamInput{TeamID: Int64(teamIDs[i])})
+		if err != nil {
+			return fmt.Errorf("team %d: %w", teamIDs[i], err)
+		}
+		names[i] = output.Team.Name
+		return nil
+	})
+	if err != nil {
+		return firstError(err)
+	}
+	for i, teamID := range teamIDs {
+		teamNames[teamID] = names[i]
+	}
+
+	for _, escalationPolicy := range escalationPolicies {
+		for i, team := range escalationPolicy.Teams {
+			if team.Name == "" {
+				escalationPolicy.Teams[i].Name = teamNames[team.ID]
+			}
+		}
+	}
+
+	return nil
+}
+
+// EscalationPolicyUpdate is a partial update of an escalation policy, only the fields that are set are sent.
+// Unlike EscalationPolicy it can set repeating to false and frequency to 0
+type EscalationPolicyUpdate struct {
+	Name            *string          `json:"name,omitempty"`
+	EscalationRules []EscalationRule `json:"escalationRules,omitempty"`
+	Repeating       *bool            `json:"repeating,omitempty"`
+	Frequency       *int             `json:"frequency,omitempty"`
+	Teams           []TeamShort      `json:"teams,omitempty"`
+}
+
 // UpdateEscalationPolicyInput represents the input of a UpdateEscalationPolicy operation.
 type UpdateEscalationPolicyInput struct {
 	_                  struct{}
 	EscalationPolicyID *int64
 	EscalationPolicy   *EscalationPolicy
+
+	// (optional) partial update sent instead of EscalationPolicy
+	Update *EscalationPolicyUpdate
 }
 
 // UpdateEscalationPolicyOutput represents the output of a UpdateEscalationPolicy operation.
@@ -146,23 +394,36 @@ func (c *Client) UpdateEscalationPolicy(input *UpdateEscalationPolicyInput) (*Up
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
-	if input.EscalationPolicy == nil {
+	if input.EscalationPolicy == nil && input.Update == nil {
 		return nil, errors.New("EscalationPolicy input is required")
 	}
+	if input.EscalationPolicy != nil && input.Update != nil {
+		return nil, errors.New("either EscalationPolicy or Update is allowed, not both")
+	}
 	if input.EscalationPolicyID == nil {
 		return nil, errors.New("escalation policy id is required")
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.EscalationPolicy).Put(fmt.Sprintf("%s/%d", apiRoutes.escalationPolicies, *input.EscalationPolicyID))
+	var body interface{} = input.EscalationPolicy
+	if input.Update != nil {
+		body = input.Update
+		if err := validateEscalationPolicy(&EscalationPolicy{EscalationRules: input.Update.EscalationRules}); err != nil {
+			return nil, err
+		}
+	} else if err := validateEscalationPolicy(input.EscalationPolicy); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.newRequest().SetBody(body).Put(fmt.Sprintf("%s/%d", apiRoutes.escalationPolicies, *input.EscalationPolicyID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	escalationPolicy := &EscalationPolicy{}
-	err = json.Unmarshal(resp.Body(), escalationPolicy)
+	err = decodeResponse("UpdateEscalationPolicy", resp, escalationPolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -190,14 +451,194 @@ func (c *Client) DeleteEscalationPolicy(input *DeleteEscalationPolicyInput) (*De
 		return nil, errors.New("EscalationPolicy id is required")
 	}
 
-	resp, err := c.httpClient.R().Delete(fmt.Sprintf("%s/%d", apiRoutes.escalationPolicies, *input.EscalationPolicyID))
+	resp, err := c.newRequest().Delete(fmt.Sprintf("%s/%d", apiRoutes.escalationPolicies, *input.EscalationPolicyID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 204); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 204); apiErr != nil {
 		return nil, apiErr
 	}
 
 	output := &DeleteEscalationPolicyOutput{}
 	return output, nil
 }
+
+// GetEscalationPolicyByName gets the escalation policy with the specified name, searching all teams regardless of WithDefaultTeam.
+// Returns an error wrapping ErrNotFound or ErrMultipleMatches if the name does not identify exactly one escalation policy.
+func (c *Client) GetEscalationPolicyByName(name string) (*EscalationPolicy, error) {
+	if name == "" {
+		return nil, errors.New("escalation policy name is required")
+	}
+
+	output, err := c.GetEscalationPolicies(&GetEscalationPoliciesInput{TeamIDs: []*int64{}})
+	if err != nil {
+		return nil, err
+	}
+
+	var match *EscalationPolicy
+	for _, escalationPolicy := range output.EscalationPolicies {
+		if escalationPolicy.Name != name {
+			continue
+		}
+		if match != nil {
+			return nil, fmt.Errorf("escalation policy %q: %w", name, ErrMultipleMatches)
+		}
+		match = escalationPolicy
+	}
+	if match == nil {
+		return nil, fmt.Errorf("escalation policy %q: %w", name, ErrNotFound)
+	}
+
+	return match, nil
+}
+
+// OnCallTarget describes a user that would currently be notified by an escalation rule
+type OnCallTarget struct {
+	RuleIndex         int       // index of the escalation rule in the policy, i.e. the escalation order
+	User              *User     // the notified user
+	Schedule          *Schedule // the schedule the user is on call for, nil if the user is a direct rule target
+	EscalationTimeout int       // minutes until the next escalation rule is notified
+}
+
+// GetEscalationPolicyOnCall resolves who would be notified right now by each rule of the escalation policy
+// with specified id. Schedule targets are resolved to their current on-call user.
+// The returned targets are in escalation order. Returns an error if a schedule has no user on call
+func (c *Client) GetEscalationPolicyOnCall(policyID int64) ([]OnCallTarget, error) {
+	output, err := c.GetEscalationPolicy(&GetEscalationPolicyInput{EscalationPolicyID: Int64(policyID)})
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]OnCallTarget, 0)
+	for i, rule := range output.EscalationPolicy.EscalationRules {
+		users := make([]*User, 0, len(rule.Users)+1)
+		if rule.User != nil {
+			users = append(users, rule.User)
+		}
+		users = append(users, rule.Users...)
+		for _, user := range users {
+			targets = append(targets, OnCallTarget{
+				RuleIndex:         i,
+				User:              user,
+				EscalationTimeout: rule.EscalationTimeout,
+			})
+		}
+
+		schedules := make([]*Schedule, 0, len(rule.Schedules)+1)
+		if rule.Schedule != nil {
+			schedules = append(schedules, rule.Schedule)
+		}
+		schedules = append(schedules, rule.Schedules...)
+		for _, schedule := range schedules {
+			onCallOutput, err := c.GetScheduleUserOnCall(&GetScheduleUserOnCallInput{ScheduleID: Int64(schedule.ID)})
+			if err != nil {
+				return nil, err
+			}
+			if onCallOutput.Shift == nil {
+				return nil, fmt.Errorf("escalation rule %d: schedule %d has no user on call", i, schedule.ID)
+			}
+			user := onCallOutput.Shift.User
+			targets = append(targets, OnCallTarget{
+				RuleIndex:         i,
+				User:              &user,
+				Schedule:          schedule,
+				EscalationTimeout: rule.EscalationTimeout,
+			})
+		}
+	}
+
+	return targets, nil
+}
+
+// PagerDutyTarget describes an escalation rule target of a PagerDuty escalation policy
+type PagerDutyTarget struct {
+	RuleIndex int    `json:"-"` // index of the escalation rule in the imported policy
+	ID        string `json:"id"`
+	Type      string `json:"type"` // e.g. user_reference or schedule_reference
+	Summary   string `json:"summary,omitempty"`
+}
+
+// PagerDutyUnmappedTargetsError lists PagerDuty targets that could not be mapped to iLert users or schedules
+type PagerDutyUnmappedTargetsError struct {
+	Targets []PagerDutyTarget
+}
+
+func (e *PagerDutyUnmappedTargetsError) Error() string {
+	targets := make([]string, 0, len(e.Targets))
+	for _, target := range e.Targets {
+		targets = append(targets, fmt.Sprintf("rule %d: %s %s", target.RuleIndex, target.Type, target.ID))
+	}
+	return fmt.Sprintf("unmapped PagerDuty escalation targets: %s", strings.Join(targets, ", "))
+}
+
+type pagerDutyEscalationPolicy struct {
+	Name            string `json:"name"`
+	NumLoops        int    `json:"num_loops"`
+	EscalationRules []struct {
+		EscalationDelayInMinutes int               `json:"escalation_delay_in_minutes"`
+		Targets                  []PagerDutyTarget `json:"targets"`
+	} `json:"escalation_rules"`
+}
+
+// ImportEscalationPolicyFromPagerDuty converts a PagerDuty escalation policy (as returned by the PagerDuty REST API v2,
+// with or without the "escalation_policy" envelope) into an iLert escalation policy.
+//
+// Mapping assumptions and limitations:
+//
+// - name is kept, escalation_delay_in_minutes becomes the escalation timeout of the rule
+//
+// - num_loops > 0 makes the policy repeating with frequency num_loops
+//
+// - PagerDuty user and schedule ids can not be translated to iLert ids, so the rule targets are left empty
+// and returned as *PagerDutyUnmappedTargetsError next to the converted policy. The caller is expected to set
+// User or Schedule of each listed rule before creating the policy
+//
+// - teams are not imported
+func ImportEscalationPolicyFromPagerDuty(data []byte) (*EscalationPolicy, error) {
+	envelope := &struct {
+		EscalationPolicy *pagerDutyEscalationPolicy `json:"escalation_policy"`
+	}{}
+	err := json.Unmarshal(data, envelope)
+	if err != nil {
+		return nil, err
+	}
+	pdPolicy := envelope.EscalationPolicy
+	if pdPolicy == nil {
+		pdPolicy = &pagerDutyEscalationPolicy{}
+		err = json.Unmarshal(data, pdPolicy)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if pdPolicy.Name == "" {
+		return nil, errors.New("PagerDuty escalation policy name is required")
+	}
+	if len(pdPolicy.EscalationRules) == 0 {
+		return nil, errors.New("PagerDuty escalation policy has no escalation rules")
+	}
+
+	escalationPolicy := &EscalationPolicy{
+		Name:            pdPolicy.Name,
+		EscalationRules: make([]EscalationRule, 0, len(pdPolicy.EscalationRules)),
+	}
+	if pdPolicy.NumLoops > 0 {
+		escalationPolicy.Repeating = true
+		escalationPolicy.Frequency = pdPolicy.NumLoops
+	}
+
+	unmapped := &PagerDutyUnmappedTargetsError{}
+	for i, pdRule := range pdPolicy.EscalationRules {
+		escalationPolicy.EscalationRules = append(escalationPolicy.EscalationRules, EscalationRule{
+			EscalationTimeout: pdRule.EscalationDelayInMinutes,
+		})
+		for _, target := range pdRule.Targets {
+			target.RuleIndex = i
+			unmapped.Targets = append(unmapped.Targets, target)
+		}
+	}
+	if len(unmapped.Targets) > 0 {
+		return escalationPolicy, unmapped
+	}
+
+	return escalationPolicy, nil
+}
@@ -0,0 +1,51 @@
+package ilert
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEscalationPolicyNameOnlyUpdateBody(t *testing.T) {
+	data, err := json.Marshal(&EscalationPolicy{Name: "ops"})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if got, want := string(data), `{"name":"ops"}`; got != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+}
+
+func TestUpdateEscalationPolicyPartialUpdateBody(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		body = string(data)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":1,"name":"ops"}`))
+	}))
+	defer server.Close()
+	client := NewClient(WithAPIEndpoint(server.URL))
+
+	tests := []struct {
+		name   string
+		update *EscalationPolicyUpdate
+		want   string
+	}{
+		{"name only", &EscalationPolicyUpdate{Name: String("ops")}, `{"name":"ops"}`},
+		{"disable repeating", &EscalationPolicyUpdate{Repeating: Bool(false), Frequency: Int(0)}, `{"repeating":false,"frequency":0}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.UpdateEscalationPolicy(&UpdateEscalationPolicyInput{EscalationPolicyID: Int64(1), Update: tt.update})
+			if err != nil {
+				t.Fatalf("UpdateEscalationPolicy: %v", err)
+			}
+			if body != tt.want {
+				t.Errorf("body = %s, want %s", body, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package ilert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// FieldDiff is a difference of a single field between a desired and an actual resource.
+// Desired or Actual is nil if the field (e.g. an escalation rule) only exists on the other side
+type FieldDiff struct {
+	Field   string // e.g. "name", "teams" or "escalationRules[1].escalationTimeout"
+	Desired interface{}
+	Actual  interface{}
+}
+
+func (d FieldDiff) String() string {
+	return fmt.Sprintf("%s: %v -> %v", d.Field, d.Actual, d.Desired)
+}
+
+// escalationRuleDiffView is the comparable form of an escalation rule
+type escalationRuleDiffView struct {
+	Users             []string
+	Schedules         []int64
+	EscalationTimeout int
+}
+
+// DiffEscalationPolicies compares a desired escalation policy with the actual one, e.g. to show the changes
+// an update would make. Server generated fields like the id and team names are ignored.
+// Teams and the users and schedules of a rule are compared regardless of their order.
+// Rules are matched by their targets and timeout, then by their targets, and the remaining rules at the same
+// position, so inserting a rule only reports that rule. Matched rules that differ are reported per field and named
+// by their index in desired. Rules only present in desired are reported with a nil Actual, rules only present in
+// actual with a nil Desired and named by their index in actual. A changed order of the matched rules is reported
+// as "escalationRules.order" with the rule indices in desired and actual. Returns no diffs if the policies are equal
+func DiffEscalationPolicies(desired, actual *EscalationPolicy) ([]FieldDiff, error) {
+	if desired == nil || actual == nil {
+		return nil, errors.New("desired and actual escalation policy are required")
+	}
+
+	diffs := make([]FieldDiff, 0)
+	add := func(field string, desired, actual interface{}) {
+		if !reflect.DeepEqual(desired, actual) {
+			diffs = append(diffs, FieldDiff{Field: field, Desired: desired, Actual: actual})
+		}
+	}
+
+	add("name", desired.Name, actual.Name)
+	add("repeating", desired.Repeating, actual.Repeating)
+	add("frequency", desired.Frequency, actual.Frequency)
+	add("teams", escalationPolicyTeamIDs(desired), escalationPolicyTeamIDs(actual))
+
+	desiredRules := make([]escalationRuleDiffView, len(desired.EscalationRules))
+	for i, rule := range desired.EscalationRules {
+		desiredRules[i] = newEscalationRuleDiffView(rule)
+	}
+	actualRules := make([]escalationRuleDiffView, len(actual.EscalationRules))
+	for i, rule := range actual.EscalationRules {
+		actualRules[i] = newEscalationRuleDiffView(rule)
+	}
+	matches := matchEscalationRules(desiredRules, actualRules)
+
+	matched := make(map[int]bool, len(matches))
+	desiredOrder := make([]int, 0, len(matches))
+	actualOrder := make([]int, 0, len(matches))
+	for i := range desiredRules {
+		field := fmt.Sprintf("escalationRules[%d]", i)
+		j, ok := matches[i]
+		if !ok {
+			diffs = append(diffs, FieldDiff{Field: field, Desired: desired.EscalationRules[i]})
+			continue
+		}
+		matched[j] = true
+		desiredOrder = append(desiredOrder, i)
+		actualOrder = append(actualOrder, j)
+
+		add(field+".users", desiredRules[i].Users, actualRules[j].Users)
+		add(field+".schedules", desiredRules[i].Schedules, actualRules[j].Schedules)
+		add(field+".escalationTimeout", desiredRules[i].EscalationTimeout, actualRules[j].EscalationTimeout)
+	}
+	for j := range actualRules {
+		if !matched[j] {
+			diffs = append(diffs, FieldDiff{Field: fmt.Sprintf("escalationRules[%d]", j), Actual: actual.EscalationRules[j]})
+		}
+	}
+	if !sort.IntsAreSorted(actualOrder) {
+		diffs = append(diffs, FieldDiff{Field: "escalationRules.order", Desired: desiredOrder, Actual: actualOrder})
+	}
+
+	return diffs, nil
+}
+
+// matchEscalationRules pairs desired with actual rules and returns the index of the actual rule by desired index.
+// Rules with equal targets and timeout are paired first, then rules with equal targets, then the remaining rules
+// at the same position
+func matchEscalationRules(desired, actual []escalationRuleDiffView) map[int]int {
+	matches := make(map[int]int)
+	used := make(map[int]bool)
+	pass := func(equal func(d, a escalationRuleDiffView) bool) {
+		for i := range desired {
+			if _, ok := matches[i]; ok {
+				continue
+			}
+			for j := range actual {
+				if !used[j] && equal(desired[i], actual[j]) {
+					matches[i] = j
+					used[j] = true
+					break
+				}
+			}
+		}
+	}
+
+	sameTargets := func(d, a escalationRuleDiffView) bool {
+		return reflect.DeepEqual(d.Users, a.Users) && reflect.DeepEqual(d.Schedules, a.Schedules)
+	}
+	pass(func(d, a escalationRuleDiffView) bool {
+		return sameTargets(d, a) && d.EscalationTimeout == a.EscalationTimeout
+	})
+	pass(sameTargets)
+
+	// remaining rules at the same position are treated as edited, e.g. a rule whose targets were replaced
+	for i := range desired {
+		if _, ok := matches[i]; !ok && i < len(actual) && !used[i] {
+			matches[i] = i
+			used[i] = true
+		}
+	}
+
+	return matches
+}
+
+func escalationPolicyTeamIDs(escalationPolicy *EscalationPolicy) []int64 {
+	teamIDs := make([]int64, 0, len(escalationPolicy.Teams))
+	for _, team := range escalationPolicy.Teams {
+		teamIDs = append(teamIDs, team.ID)
+	}
+	sort.Slice(teamIDs, func(i, j int) bool { return teamIDs[i] < teamIDs[j] })
+	return teamIDs
+}
+
+// newEscalationRuleDiffView merges the single and multiple users and schedules of the rule into sorted lists,
+// users are identified by id or, if the id is not set, by username
+func newEscalationRuleDiffView(rule EscalationRule) escalationRuleDiffView {
+	view := escalationRuleDiffView{
+		Users:             make([]string, 0),
+		Schedules:         make([]int64, 0),
+		EscalationTimeout: rule.EscalationTimeout,
+	}
+
+	users := rule.Users
+	if rule.User != nil {
+		users = append([]*User{rule.User}, users...)
+	}
+	for _, user := range users {
+		if user == nil {
+			continue
+		}
+		key := user.Username
+		if user.ID != 0 {
+			key = strconv.FormatInt(user.ID, 10)
+		}
+		if !stringSliceContains(view.Users, key) {
+			view.Users = append(view.Users, key)
+		}
+	}
+
+	schedules := rule.Schedules
+	if rule.Schedule != nil {
+		schedules = append([]*Schedule{rule.Schedule}, schedules...)
+	}
+	for _, schedule := range schedules {
+		if schedule != nil && !int64SliceContains(view.Schedules, schedule.ID) {
+			view.Schedules = append(view.Schedules, schedule.ID)
+		}
+	}
+
+	sort.Strings(view.Users)
+	sort.Slice(view.Schedules, func(i, j int) bool { return view.Schedules[i] < view.Schedules[j] })
+
+	return view
+}
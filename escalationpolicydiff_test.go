@@ -0,0 +1,115 @@
+package ilert
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffEscalationPolicies(t *testing.T) {
+	ruleA := EscalationRule{User: &User{ID: 1}, EscalationTimeout: 5}
+	ruleB := EscalationRule{Schedule: &Schedule{ID: 10}, EscalationTimeout: 10}
+	ruleC := EscalationRule{Users: []*User{{ID: 2}, {ID: 3}}, EscalationTimeout: 15}
+	policy := func(name string, teams []TeamShort, rules ...EscalationRule) *EscalationPolicy {
+		return &EscalationPolicy{Name: name, Teams: teams, EscalationRules: rules}
+	}
+
+	tests := []struct {
+		name    string
+		desired *EscalationPolicy
+		actual  *EscalationPolicy
+		want    []string // fields of the expected diffs
+	}{
+		{
+			name:    "equal",
+			desired: policy("ops", nil, ruleA, ruleB),
+			actual:  policy("ops", nil, ruleA, ruleB),
+			want:    []string{},
+		},
+		{
+			name:    "rename",
+			desired: policy("ops-eu", nil, ruleA),
+			actual:  policy("ops", nil, ruleA),
+			want:    []string{"name"},
+		},
+		{
+			name:    "rule added at the front",
+			desired: policy("ops", nil, ruleC, ruleA, ruleB),
+			actual:  policy("ops", nil, ruleA, ruleB),
+			want:    []string{"escalationRules[0]"},
+		},
+		{
+			name:    "rule removed",
+			desired: policy("ops", nil, ruleA, ruleC),
+			actual:  policy("ops", nil, ruleA, ruleB, ruleC),
+			want:    []string{"escalationRules[1]"},
+		},
+		{
+			name:    "rule timeout changed",
+			desired: policy("ops", nil, ruleA, EscalationRule{Schedule: &Schedule{ID: 10}, EscalationTimeout: 30}),
+			actual:  policy("ops", nil, ruleA, ruleB),
+			want:    []string{"escalationRules[1].escalationTimeout"},
+		},
+		{
+			name:    "rule targets changed",
+			desired: policy("ops", nil, ruleA, EscalationRule{Schedule: &Schedule{ID: 11}, EscalationTimeout: 10}),
+			actual:  policy("ops", nil, ruleA, ruleB),
+			want:    []string{"escalationRules[1].schedules"},
+		},
+		{
+			name:    "reordered teams and targets",
+			desired: policy("ops", []TeamShort{{ID: 2}, {ID: 1}}, EscalationRule{Users: []*User{{ID: 3}, {ID: 2}}, EscalationTimeout: 15}),
+			actual:  policy("ops", []TeamShort{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}, ruleC),
+			want:    []string{},
+		},
+		{
+			name:    "single and multiple targets",
+			desired: policy("ops", nil, EscalationRule{Users: []*User{{ID: 1}}, EscalationTimeout: 5}),
+			actual:  policy("ops", nil, ruleA),
+			want:    []string{},
+		},
+		{
+			name:    "reordered rules",
+			desired: policy("ops", nil, ruleB, ruleA),
+			actual:  policy("ops", nil, ruleA, ruleB),
+			want:    []string{"escalationRules.order"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diffs, err := DiffEscalationPolicies(tt.desired, tt.actual)
+			if err != nil {
+				t.Fatalf("DiffEscalationPolicies: %v", err)
+			}
+			fields := make([]string, 0, len(diffs))
+			for _, diff := range diffs {
+				fields = append(fields, diff.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.want) {
+				t.Errorf("diffs = %v, want fields %v", diffs, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffEscalationPoliciesAddedAndRemovedRules(t *testing.T) {
+	ruleA := EscalationRule{User: &User{ID: 1}, EscalationTimeout: 5}
+	ruleB := EscalationRule{Schedule: &Schedule{ID: 10}, EscalationTimeout: 10}
+	ruleC := EscalationRule{User: &User{ID: 2}, EscalationTimeout: 15}
+
+	diffs, err := DiffEscalationPolicies(
+		&EscalationPolicy{Name: "ops", EscalationRules: []EscalationRule{ruleC, ruleA}},
+		&EscalationPolicy{Name: "ops", EscalationRules: []EscalationRule{ruleA, ruleB}},
+	)
+	if err != nil {
+		t.Fatalf("DiffEscalationPolicies: %v", err)
+	}
+	if len(diffs) != 2 {
+		t.Fatalf("diffs = %v, want 2 diffs", diffs)
+	}
+	if diffs[0].Actual != nil || !reflect.DeepEqual(diffs[0].Desired, ruleC) {
+		t.Errorf("added rule diff = %v, want desired %v and nil actual", diffs[0], ruleC)
+	}
+	if diffs[1].Field != "escalationRules[1]" || diffs[1].Desired != nil || !reflect.DeepEqual(diffs[1].Actual, ruleB) {
+		t.Errorf("removed rule diff = %v, want escalationRules[1] with actual %v and nil desired", diffs[1], ruleB)
+	}
+}
@@ -1,8 +1,14 @@
 package ilert
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
 )
 
 // Event represents the incident event https://api.ilert.com/api-docs/#tag/Events
@@ -46,11 +52,106 @@ var EventTypes = struct {
 	Resolve: "RESOLVE",
 }
 
+// CustomDetailsTypes defines custom details value types used by WithCustomDetailsSchema
+var CustomDetailsTypes = struct {
+	String  string
+	Number  string
+	Boolean string
+	Object  string
+	Array   string
+}{
+	String:  "string",
+	Number:  "number",
+	Boolean: "boolean",
+	Object:  "object",
+	Array:   "array",
+}
+
+// CustomDetailsValidationError describes custom details that do not match the configured schema
+type CustomDetailsValidationError struct {
+	Missing  []string
+	Mistyped []string
+}
+
+func (e *CustomDetailsValidationError) Error() string {
+	problems := make([]string, 0, 2)
+	if len(e.Missing) > 0 {
+		problems = append(problems, fmt.Sprintf("missing keys: %s", strings.Join(e.Missing, ", ")))
+	}
+	if len(e.Mistyped) > 0 {
+		problems = append(problems, fmt.Sprintf("mistyped keys: %s", strings.Join(e.Mistyped, ", ")))
+	}
+	return fmt.Sprintf("custom details do not match schema, %s", strings.Join(problems, "; "))
+}
+
+// validateCustomDetails checks that every key of the schema is present in the custom details with the declared type
+func validateCustomDetails(schema map[string]string, customDetails map[string]interface{}) error {
+	if len(schema) == 0 {
+		return nil
+	}
+
+	validationErr := &CustomDetailsValidationError{}
+	for key, valueType := range schema {
+		value, ok := customDetails[key]
+		if !ok {
+			validationErr.Missing = append(validationErr.Missing, key)
+			continue
+		}
+		if !isCustomDetailsType(value, valueType) {
+			validationErr.Mistyped = append(validationErr.Mistyped, fmt.Sprintf("%s (expected %s)", key, valueType))
+		}
+	}
+	if len(validationErr.Missing) == 0 && len(validationErr.Mistyped) == 0 {
+		return nil
+	}
+	sort.Strings(validationErr.Missing)
+	sort.Strings(validationErr.Mistyped)
+
+	return validationErr
+}
+
+func isCustomDetailsType(value interface{}, valueType string) bool {
+	switch value.(type) {
+	case string:
+		return valueType == CustomDetailsTypes.String
+	case bool:
+		return valueType == CustomDetailsTypes.Boolean
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, json.Number:
+		return valueType == CustomDetailsTypes.Number
+	case map[string]interface{}:
+		return valueType == CustomDetailsTypes.Object
+	case []interface{}:
+		return valueType == CustomDetailsTypes.Array
+	}
+	return false
+}
+
 // EventResponse describes event API response body
 type EventResponse struct {
 	IncidentKey  string `json:"incidentKey"`
 	IncidentURL  string `json:"incidentUrl"`
-	ResponseCode string `json:"responseCode"`
+	ResponseCode string `json:"responseCode"` // one of EventResponseStatuses
+}
+
+// EventResponseStatuses defines the response codes of the event API, see EventResponse.ResponseCode
+var EventResponseStatuses = struct {
+	NewIncidentCreated string
+	IncidentAccepted   string
+	IncidentResolved   string
+	EventIgnored       string
+}{
+	NewIncidentCreated: "NEW_INCIDENT_CREATED",
+	IncidentAccepted:   "INCIDENT_ACCEPTED",
+	IncidentResolved:   "INCIDENT_RESOLVED",
+	EventIgnored:       "EVENT_IGNORED",
+}
+
+// EventResponseStatusesAll defines all event response statuses
+var EventResponseStatusesAll = []string{
+	EventResponseStatuses.NewIncidentCreated,
+	EventResponseStatuses.IncidentAccepted,
+	EventResponseStatuses.IncidentResolved,
+	EventResponseStatuses.EventIgnored,
 }
 
 // CreateEventInput represents the input of a CreateEvent operation.
@@ -60,12 +161,21 @@ type CreateEventInput struct {
 	Event *Event
 	// (optional) request url
 	URL *string
+	// (optional) text/template rendered with the custom details of the event as summary,
+	// e.g. "{{.service}} down in {{.region}}". Missing custom details fail the event
+	SummaryTemplate *string
+	// (optional) text/template rendered with the custom details of the event as details
+	DetailsTemplate *string
 }
 
 // CreateEventOutput represents the output of a CreateEvent operation.
 type CreateEventOutput struct {
 	_             struct{}
 	EventResponse *EventResponse
+
+	// true if the API accepted the event for asynchronous processing (202 Accepted), the incident may not
+	// exist yet and EventResponse may be empty
+	Pending bool
 }
 
 // CreateEvent creates an incident event. https://api.ilert.com/api-docs/#tag/Events/paths/~1events/post
@@ -76,22 +186,209 @@ func (c *Client) CreateEvent(input *CreateEventInput) (*CreateEventOutput, error
 	if input.Event == nil {
 		return nil, errors.New("input event is required")
 	}
+	if err := validateCustomDetails(c.customDetailsSchema, input.Event.CustomDetails); err != nil {
+		return nil, err
+	}
+	event, err := renderEventTemplates(input.Event, input.SummaryTemplate, input.DetailsTemplate)
+	if err != nil {
+		return nil, err
+	}
 	url := apiRoutes.events
 	if input.URL != nil && *input.URL != "" {
 		url = *input.URL
 	}
-	resp, err := c.httpClient.R().SetBody(input.Event).Post(url)
+	resp, err := c.newRequest().SetBody(event).Post(url)
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200, 202); apiErr != nil {
 		return nil, apiErr
 	}
+	pending := resp.StatusCode() == 202
 	eventResponse := &EventResponse{}
-	err = json.Unmarshal(resp.Body(), eventResponse)
+	if !pending || len(bytes.TrimSpace(resp.Body())) > 0 {
+		err = decodeResponse("CreateEvent", resp, eventResponse)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &CreateEventOutput{EventResponse: eventResponse, Pending: pending}, nil
+}
+
+// renderEventTemplates returns a copy of the event with the summary and details rendered from the templates,
+// or the event itself if there are no templates
+func renderEventTemplates(event *Event, summaryTemplate *string, detailsTemplate *string) (*Event, error) {
+	if summaryTemplate == nil && detailsTemplate == nil {
+		return event, nil
+	}
+
+	rendered := *event
+	if summaryTemplate != nil {
+		summary, err := renderCustomDetailsTemplate("summary", *summaryTemplate, event.CustomDetails)
+		if err != nil {
+			return nil, err
+		}
+		rendered.Summary = summary
+	}
+	if detailsTemplate != nil {
+		details, err := renderCustomDetailsTemplate("details", *detailsTemplate, event.CustomDetails)
+		if err != nil {
+			return nil, err
+		}
+		rendered.Details = details
+	}
+
+	return &rendered, nil
+}
+
+func renderCustomDetailsTemplate(name string, text string, customDetails map[string]interface{}) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
 	if err != nil {
-		return nil, err
+		return "", fmt.Errorf("invalid %s template: %w", name, err)
+	}
+	if customDetails == nil {
+		customDetails = map[string]interface{}{}
+	}
+	b := &strings.Builder{}
+	if err := tmpl.Execute(b, customDetails); err != nil {
+		return "", fmt.Errorf("rendering %s template: %w", name, err)
+	}
+	return b.String(), nil
+}
+
+// CreateIncidentIfUnderThreshold sends the alert event only if the alert source of the event (identified by the
+// api key of the event) has fewer than maxOpen open incidents. Returns whether the event was sent.
+// The check and the event are separate calls, so concurrent callers may together exceed maxOpen
+func (c *Client) CreateIncidentIfUnderThreshold(input *CreateEventInput, maxOpen int) (*EventResponse, bool, error) {
+	if input == nil {
+		return nil, false, errors.New("input is required")
+	}
+	if input.Event == nil {
+		return nil, false, errors.New("input event is required")
+	}
+	if input.Event.APIKey == "" {
+		return nil, false, errors.New("event api key is required")
+	}
+	if maxOpen <= 0 {
+		return nil, false, errors.New("maxOpen must be positive")
+	}
+
+	alertSourcesOutput, err := c.GetAlertSources(&GetAlertSourcesInput{})
+	if err != nil {
+		return nil, false, err
+	}
+	var alertSource *AlertSource
+	for _, a := range alertSourcesOutput.AlertSources {
+		if a.IntegrationKey == input.Event.APIKey {
+			alertSource = a
+			break
+		}
+	}
+	if alertSource == nil {
+		return nil, false, fmt.Errorf("alert source of the event api key: %w", ErrNotFound)
+	}
+
+	countOutput, err := c.GetIncidentsCount(&GetIncidentsCountInput{
+		AlertSources: []*int64{Int64(alertSource.ID)},
+		States: []*string{
+			String(IncidentStatuses.New),
+			String(IncidentStatuses.Pending),
+			String(IncidentStatuses.Accepted),
+		},
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if countOutput.Count >= maxOpen {
+		return nil, false, nil
+	}
+
+	output, err := c.CreateEvent(input)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return output.EventResponse, true, nil
+}
+
+// CreateEventsInput represents the input of a CreateEvents operation.
+type CreateEventsInput struct {
+	_ struct{}
+	// incident events
+	Events []*Event
+	// (optional) request url
+	URL *string
+	// (optional) maximum number of events submitted in parallel. Default: 5
+	Concurrency *int
+	// (optional) summary template of all events, see CreateEventInput
+	SummaryTemplate *string
+	// (optional) details template of all events, see CreateEventInput
+	DetailsTemplate *string
+}
+
+// CreateEventResult describes the result of a single event of a CreateEvents operation.
+type CreateEventResult struct {
+	Event         *Event
+	EventResponse *EventResponse
+	Error         error
+}
+
+// CreateEventsOutput represents the output of a CreateEvents operation.
+type CreateEventsOutput struct {
+	_ struct{}
+	// results in the same order as the input events
+	Results []*CreateEventResult
+}
+
+// CreateEvents creates multiple incident events. The events API has no batch endpoint,
+// so the events are submitted in parallel with bounded concurrency using CreateEvent.
+// Rate limited requests (429) are retried according to the retry settings of the client.
+// A failed event does not stop the other events, check the Error of each result.
+func (c *Client) CreateEvents(input *CreateEventsInput) (*CreateEventsOutput, error) {
+	return c.CreateEventsWithContext(context.Background(), input)
+}
+
+// CreateEventsWithContext creates multiple incident events like CreateEvents. When the context is cancelled no
+// further events are submitted, requests in flight are aborted and the results of the remaining events carry the
+// context error.
+func (c *Client) CreateEventsWithContext(ctx context.Context, input *CreateEventsInput) (*CreateEventsOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if len(input.Events) == 0 {
+		return nil, errors.New("input events are required")
+	}
+	if input.Concurrency != nil && *input.Concurrency < 1 {
+		return nil, errors.New("concurrency must be at least 1")
+	}
+
+	results := make([]*CreateEventResult, len(input.Events))
+	err := batchExecute(ctx, len(input.Events), bulkConcurrency(input.Concurrency), false, func(ctx context.Context, i int) error {
+		result := &CreateEventResult{Event: input.Events[i]}
+		results[i] = result
+		output, err := c.withContext(ctx).CreateEvent(&CreateEventInput{
+			Event:           input.Events[i],
+			URL:             input.URL,
+			SummaryTemplate: input.SummaryTemplate,
+			DetailsTemplate: input.DetailsTemplate,
+		})
+		if err != nil {
+			result.Error = err
+			return err
+		}
+		result.EventResponse = output.EventResponse
+		return nil
+	})
+
+	bulkErr := &BulkError{}
+	if errors.As(err, &bulkErr) {
+		for i, result := range results {
+			if result == nil {
+				results[i] = &CreateEventResult{Event: input.Events[i], Error: bulkErr.Errors[i]}
+			}
+		}
 	}
 
-	return &CreateEventOutput{EventResponse: eventResponse}, nil
+	return &CreateEventsOutput{Results: results}, nil
 }
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/iLert/ilert-go"
+)
+
+func main() {
+	// set your environment variables:
+	// ILERT_ORGANIZATION="your organization"
+	// ILERT_USERNAME="your username"
+	// ILERT_PASSWORD="your password"
+	client := ilert.NewClient()
+
+	var alertSourceID int64 = 123
+	start := time.Now()
+	result, err := client.CreateMaintenanceWindow(&ilert.CreateMaintenanceWindowInput{
+		MaintenanceWindow: &ilert.MaintenanceWindow{
+			Summary:      "Release 1.2.3",
+			Start:        start.Format(time.RFC3339),
+			End:          start.Add(30 * time.Minute).Format(time.RFC3339),
+			AlertSources: []ilert.AlertSourceShort{{ID: alertSourceID}},
+		},
+	})
+	if err != nil {
+		log.Println(result)
+		log.Fatalln("ERROR:", err)
+	}
+	log.Printf("Maintenance window %d created\n", result.MaintenanceWindow.ID)
+
+	// ... deploy ...
+
+	_, err = client.DeleteMaintenanceWindow(&ilert.DeleteMaintenanceWindowInput{
+		MaintenanceWindowID: ilert.Int64(result.MaintenanceWindow.ID),
+	})
+	if err != nil {
+		log.Fatalln("ERROR:", err)
+	}
+	log.Println("Maintenance window closed")
+}
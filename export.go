@@ -0,0 +1,278 @@
+package ilert
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// IncidentExportFields defines the incident fields supported by ExportIncidents, in their default order
+var IncidentExportFields = []string{"id", "summary", "status", "priority", "reportTime", "resolvedOn", "assignedTo"}
+
+// incidentExportValue returns the CSV value of the incident field
+func incidentExportValue(incident *Incident, field string) string {
+	switch field {
+	case "id":
+		return strconv.FormatInt(incident.ID, 10)
+	case "summary":
+		return incident.Summary
+	case "status":
+		return incident.Status
+	case "priority":
+		return incident.Priority
+	case "reportTime":
+		return incident.ReportTime
+	case "resolvedOn":
+		return incident.ResolvedOn
+	case "assignedTo":
+		if incident.AssignedTo != nil {
+			return incident.AssignedTo.Username
+		}
+	}
+	return ""
+}
+
+// ExportIncidents writes all incidents matching the input filters as CSV with a header row and one column per field
+// (see IncidentExportFields, all fields if empty). The incidents are written page by page while paging through
+// the results, so memory usage does not grow with the number of incidents
+func (c *Client) ExportIncidents(input *GetIncidentsInput, fields []string, w io.Writer) error {
+	if w == nil {
+		return errors.New("writer is required")
+	}
+	if len(fields) == 0 {
+		fields = IncidentExportFields
+	}
+	for _, field := range fields {
+		if !stringSliceContains(IncidentExportFields, field) {
+			return fmt.Errorf("invalid incident export field %q", field)
+		}
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(fields); err != nil {
+		return err
+	}
+
+	row := make([]string, len(fields))
+	err := c.forEachIncidentsPage(input, func(incidents []*Incident) error {
+		for _, incident := range incidents {
+			for i, field := range fields {
+				row[i] = incidentExportValue(incident, field)
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// redactedValue replaces secrets in exports
+const redactedValue = "REDACTED"
+
+// OrganizationExport is the document written by ExportAll
+type OrganizationExport struct {
+	ExportedAt         string               `json:"exportedAt"` // Date time string in ISO format
+	AlertSources       []*AlertSource       `json:"alertSources,omitempty"`
+	Connections        []*ConnectionOutput  `json:"connections,omitempty"`
+	Connectors         []*ConnectorOutput   `json:"connectors,omitempty"`
+	EscalationPolicies []*EscalationPolicy  `json:"escalationPolicies,omitempty"`
+	MaintenanceWindows []*MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+	Schedules          []*Schedule          `json:"schedules,omitempty"`
+	Teams              []*Team              `json:"teams,omitempty"`
+	UptimeMonitors     []*UptimeMonitor     `json:"uptimeMonitors,omitempty"`
+	Users              []*User              `json:"users,omitempty"`
+
+	// resource types that could not be exported, with the error message
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// ExportError lists the resource types ExportAll failed to fetch
+type ExportError struct {
+	Errors map[string]error
+}
+
+func (e *ExportError) Error() string {
+	types := make([]string, 0, len(e.Errors))
+	for resourceType := range e.Errors {
+		types = append(types, resourceType)
+	}
+	sort.Strings(types)
+
+	messages := make([]string, 0, len(types))
+	for _, resourceType := range types {
+		messages = append(messages, fmt.Sprintf("%s: %s", resourceType, e.Errors[resourceType]))
+	}
+	return fmt.Sprintf("export failed for %s", strings.Join(messages, "; "))
+}
+
+// ExportOption configures ExportAll
+type ExportOption func(*exportOptions)
+
+type exportOptions struct {
+	includeSecrets bool
+}
+
+// ExportWithSecrets includes secrets (connector credentials, alert source integration keys) in the export,
+// by default they are redacted
+func ExportWithSecrets() ExportOption {
+	return func(o *exportOptions) {
+		o.includeSecrets = true
+	}
+}
+
+// ExportAll writes a JSON snapshot (see OrganizationExport) of the alert sources, connections, connectors,
+// escalation policies, maintenance windows, schedules, teams, uptime monitors and users of the organization.
+// All teams are exported regardless of WithDefaultTeam.
+// The resource types are fetched concurrently, schedules and users are paged through.
+// Resource types that fail are left out of the document and listed in its errors,
+// the document is written nevertheless and an *ExportError is returned.
+// Secrets are redacted unless ExportWithSecrets is given
+func (c *Client) ExportAll(ctx context.Context, w io.Writer, options ...ExportOption) error {
+	if w == nil {
+		return errors.New("writer is required")
+	}
+	opts := &exportOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+
+	client := c.withContext(ctx)
+	export := &OrganizationExport{ExportedAt: time.Now().UTC().Format(time.RFC3339)}
+	exportErr := &ExportError{Errors: make(map[string]error)}
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	fetch := func(resourceType string, fn func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(); err != nil {
+				mu.Lock()
+				exportErr.Errors[resourceType] = err
+				mu.Unlock()
+			}
+		}()
+	}
+
+	fetch("alertSources", func() error {
+		output, err := client.GetAlertSources(&GetAlertSourcesInput{})
+		if err == nil {
+			export.AlertSources = output.AlertSources
+		}
+		return err
+	})
+	fetch("connections", func() error {
+		output, err := client.GetConnections(&GetConnectionsInput{})
+		if err == nil {
+			export.Connections = output.Connections
+		}
+		return err
+	})
+	fetch("connectors", func() error {
+		output, err := client.GetConnectors(&GetConnectorsInput{})
+		if err == nil {
+			export.Connectors = output.Connectors
+		}
+		return err
+	})
+	fetch("escalationPolicies", func() error {
+		output, err := client.GetEscalationPolicies(&GetEscalationPoliciesInput{TeamIDs: []*int64{}})
+		if err == nil {
+			export.EscalationPolicies = output.EscalationPolicies
+		}
+		return err
+	})
+	fetch("maintenanceWindows", func() error {
+		output, err := client.GetMaintenanceWindows(&GetMaintenanceWindowsInput{})
+		if err == nil {
+			export.MaintenanceWindows = output.MaintenanceWindows
+		}
+		return err
+	})
+	fetch("schedules", func() error {
+		schedules, err := client.getAllSchedules(GetSchedulesInput{TeamIDs: []*int64{}})
+		if err == nil {
+			export.Schedules = schedules
+		}
+		return err
+	})
+	fetch("teams", func() error {
+		output, err := client.GetTeams(&GetTeamsInput{})
+		if err == nil {
+			export.Teams = output.Teams
+		}
+		return err
+	})
+	fetch("uptimeMonitors", func() error {
+		output, err := client.GetUptimeMonitors(&GetUptimeMonitorsInput{})
+		if err == nil {
+			export.UptimeMonitors = output.UptimeMonitors
+		}
+		return err
+	})
+	fetch("users", func() error {
+		users, err := client.getAllUsers(GetUsersInput{})
+		if err == nil {
+			export.Users = users
+		}
+		return err
+	})
+	wg.Wait()
+
+	if !opts.includeSecrets {
+		redactExport(export)
+	}
+	if len(exportErr.Errors) > 0 {
+		export.Errors = make(map[string]string, len(exportErr.Errors))
+		for resourceType, err := range exportErr.Errors {
+			export.Errors[resourceType] = err.Error()
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(export); err != nil {
+		return err
+	}
+
+	if len(exportErr.Errors) > 0 {
+		return exportErr
+	}
+	return nil
+}
+
+// redactExport replaces the secrets of the exported resources
+func redactExport(export *OrganizationExport) {
+	redact := func(s *string) {
+		if *s != "" {
+			*s = redactedValue
+		}
+	}
+	for _, alertSource := range export.AlertSources {
+		redact(&alertSource.IntegrationKey)
+		redact(&alertSource.IntegrationURL)
+	}
+	for _, connector := range export.Connectors {
+		redact(&connector.Params.APIKey)
+		redact(&connector.Params.Authorization)
+		redact(&connector.Params.Password)
+	}
+}
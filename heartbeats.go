@@ -1,8 +1,10 @@
 package ilert
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"time"
 )
 
 // HeartbeatMethods defines uptime monitor regions
@@ -28,8 +30,13 @@ type PingHeartbeatOutput struct {
 	_ struct{}
 }
 
-// PingHeartbeat gets list available iLert phone numbers. https://api.ilert.com/api-docs/#tag/Heartbeats/paths/~1heartbeats~1{key}/get
+// PingHeartbeat pings the heartbeat with specified api key. https://api.ilert.com/api-docs/#tag/Heartbeats/paths/~1heartbeats~1{key}/get
 func (c *Client) PingHeartbeat(input *PingHeartbeatInput) (*PingHeartbeatOutput, error) {
+	return c.PingHeartbeatWithContext(context.Background(), input)
+}
+
+// PingHeartbeatWithContext pings the heartbeat with specified api key, the request is aborted when the context is cancelled.
+func (c *Client) PingHeartbeatWithContext(ctx context.Context, input *PingHeartbeatInput) (*PingHeartbeatOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -40,13 +47,49 @@ func (c *Client) PingHeartbeat(input *PingHeartbeatInput) (*PingHeartbeatOutput,
 		input.Method = String(HeartbeatMethods.HEAD)
 	}
 
-	resp, err := c.httpClient.R().Execute(*input.Method, fmt.Sprintf("%s/%s", apiRoutes.heartbeats, *input.APIKey))
+	resp, err := c.newRequestWithContext(ctx).Execute(*input.Method, fmt.Sprintf("%s/%s", apiRoutes.heartbeats, *input.APIKey))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 202); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 202); apiErr != nil {
 		return nil, apiErr
 	}
 
 	return &PingHeartbeatOutput{}, nil
 }
+
+// StartHeartbeatPinger starts a goroutine that pings the heartbeat with specified api key right away and then
+// at every interval until the context is cancelled. Failed pings are reported to onError, which may be nil.
+// onError is called from the pinger goroutine
+func (c *Client) StartHeartbeatPinger(ctx context.Context, heartbeatKey string, interval time.Duration, onError func(error)) error {
+	if heartbeatKey == "" {
+		return errors.New("heartbeat key is required")
+	}
+	if interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+
+	ping := func() {
+		_, err := c.PingHeartbeatWithContext(ctx, &PingHeartbeatInput{APIKey: String(heartbeatKey)})
+		if err != nil && ctx.Err() == nil && onError != nil {
+			onError(err)
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		ping()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				ping()
+			}
+		}
+	}()
+
+	return nil
+}
@@ -1,5 +1,11 @@
 package ilert
 
+import (
+	"errors"
+	"reflect"
+	"time"
+)
+
 // String returns a pointer to the string value passed in.
 func String(v string) *string {
 	return &v
@@ -15,6 +21,11 @@ func Int(v int) *int {
 	return &v
 }
 
+// Bool returns a pointer to the bool value passed in.
+func Bool(v bool) *bool {
+	return &v
+}
+
 func intSliceContains(s []int, e int) bool {
 	for _, a := range s {
 		if a == e {
@@ -23,3 +34,56 @@ func intSliceContains(s []int, e int) bool {
 	}
 	return false
 }
+
+func stringSliceContains(s []string, e string) bool {
+	for _, a := range s {
+		if a == e {
+			return true
+		}
+	}
+	return false
+}
+
+func int64SliceContains(s []int64, e int64) bool {
+	for _, a := range s {
+		if a == e {
+			return true
+		}
+	}
+	return false
+}
+
+// timeLayouts are the ISO 8601 layouts used by the API, timestamps without zone are in UTC
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+}
+
+// parseILERTTime parses a date time string in ISO format, trying the known layouts of the API
+// and then the additional layouts in order
+func parseILERTTime(s string, additionalLayouts ...string) (time.Time, error) {
+	var firstErr error
+	for _, layouts := range [][]string{timeLayouts, additionalLayouts} {
+		for _, layout := range layouts {
+			t, err := time.Parse(layout, s)
+			if err == nil {
+				return t, nil
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return time.Time{}, firstErr
+}
+
+// validateOutput checks that out can be used as json.Unmarshal target
+func validateOutput(out interface{}) error {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return errors.New("output must be a non-nil pointer")
+	}
+	return nil
+}
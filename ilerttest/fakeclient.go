@@ -0,0 +1,516 @@
+// Package ilerttest provides an in-memory fake of the iLert API client for unit tests
+package ilerttest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/iLert/ilert-go"
+)
+
+// FakeClient is an in-memory implementation of ilert.ClientAPI. Incidents, connectors and escalation policies
+// are stored in memory, all other operations are delegated to the embedded ClientAPI, which is nil by default
+// and therefore panics when such an operation is called. Set ClientAPI to stub the remaining operations, e.g.
+//
+//	fake := ilerttest.NewFakeClient()
+//	fake.AddIncident(&ilert.Incident{ID: 1, Status: ilert.IncidentStatuses.New})
+//	service := NewService(fake) // NewService(client ilert.ClientAPI)
+type FakeClient struct {
+	ilert.ClientAPI
+
+	mu                 sync.Mutex
+	nextID             int64
+	incidents          map[int64]*ilert.Incident
+	connectors         map[string]*ilert.ConnectorOutput
+	escalationPolicies map[int64]*ilert.EscalationPolicy
+}
+
+var _ ilert.ClientAPI = (*FakeClient)(nil)
+
+// NewFakeClient creates an empty fake client
+func NewFakeClient() *FakeClient {
+	return &FakeClient{
+		incidents:          make(map[int64]*ilert.Incident),
+		connectors:         make(map[string]*ilert.ConnectorOutput),
+		escalationPolicies: make(map[int64]*ilert.EscalationPolicy),
+	}
+}
+
+func (f *FakeClient) newID() int64 {
+	f.nextID++
+	return f.nextID
+}
+
+func notFoundError(resource string, id interface{}) error {
+	return &ilert.GenericAPIError{
+		Status:  404,
+		Code:    "NOT_FOUND",
+		Message: fmt.Sprintf("%s %v not found", resource, id),
+	}
+}
+
+// AddIncident stores the incident, incidents are created through events in iLert and therefore have to be seeded.
+// An incident without id gets a generated id
+func (f *FakeClient) AddIncident(incident *ilert.Incident) *ilert.Incident {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored := *incident
+	if stored.ID == 0 {
+		stored.ID = f.newID()
+	} else if stored.ID > f.nextID {
+		f.nextID = stored.ID
+	}
+	f.incidents[stored.ID] = &stored
+
+	result := stored
+	return &result
+}
+
+// GetIncident gets the stored incident with specified id
+func (f *FakeClient) GetIncident(input *ilert.GetIncidentInput) (*ilert.GetIncidentOutput, error) {
+	return f.GetIncidentWithContext(context.Background(), input)
+}
+
+// GetIncidentWithContext gets the stored incident with specified id
+func (f *FakeClient) GetIncidentWithContext(ctx context.Context, input *ilert.GetIncidentInput) (*ilert.GetIncidentOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if input.IncidentID == nil {
+		return nil, errors.New("Incident id is required")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	incident, ok := f.incidents[*input.IncidentID]
+	if !ok {
+		return nil, notFoundError("incident", *input.IncidentID)
+	}
+	result := *incident
+
+	return &ilert.GetIncidentOutput{Incident: &result}, nil
+}
+
+// IncidentExists checks if an incident with specified id is stored
+func (f *FakeClient) IncidentExists(id int64) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.incidents[id]
+	return ok, nil
+}
+
+// GetIncidents lists the stored incidents ordered by id, filtered by state and alert source
+func (f *FakeClient) GetIncidents(input *ilert.GetIncidentsInput) (*ilert.GetIncidentsOutput, error) {
+	if input == nil {
+		input = &ilert.GetIncidentsInput{}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	incidents := make([]*ilert.Incident, 0)
+	for _, incident := range f.incidents {
+		if len(input.States) > 0 && !containsString(input.States, incident.Status) {
+			continue
+		}
+		if len(input.AlertSources) > 0 && (incident.AlertSource == nil || !containsInt64(input.AlertSources, incident.AlertSource.ID)) {
+			continue
+		}
+		result := *incident
+		incidents = append(incidents, &result)
+	}
+	sort.Slice(incidents, func(i, j int) bool {
+		return incidents[i].ID < incidents[j].ID
+	})
+
+	if input.StartIndex != nil {
+		if *input.StartIndex >= len(incidents) {
+			incidents = incidents[:0]
+		} else if *input.StartIndex > 0 {
+			incidents = incidents[*input.StartIndex:]
+		}
+	}
+	if input.MaxResults != nil && *input.MaxResults >= 0 && *input.MaxResults < len(incidents) {
+		incidents = incidents[:*input.MaxResults]
+	}
+
+	return &ilert.GetIncidentsOutput{Incidents: incidents}, nil
+}
+
+// AcceptIncident sets the status of the stored incident to accepted
+func (f *FakeClient) AcceptIncident(input *ilert.AcceptIncidentInput) (*ilert.AcceptIncidentOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	incident, err := f.setIncidentStatus(input.IncidentID, ilert.IncidentStatuses.Accepted)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ilert.AcceptIncidentOutput{Incident: incident}, nil
+}
+
+// ResolveIncident sets the status of the stored incident to resolved
+func (f *FakeClient) ResolveIncident(input *ilert.ResolveIncidentInput) (*ilert.ResolveIncidentOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	incident, err := f.setIncidentStatus(input.IncidentID, ilert.IncidentStatuses.Resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ilert.ResolveIncidentOutput{Incident: incident}, nil
+}
+
+func (f *FakeClient) setIncidentStatus(incidentID *int64, status string) (*ilert.Incident, error) {
+	if incidentID == nil {
+		return nil, errors.New("Incident id is required")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	incident, ok := f.incidents[*incidentID]
+	if !ok {
+		return nil, notFoundError("incident", *incidentID)
+	}
+	incident.Status = status
+	result := *incident
+
+	return &result, nil
+}
+
+// UpdateIncident replaces the stored incident with specified id
+func (f *FakeClient) UpdateIncident(input *ilert.UpdateIncidentInput) (*ilert.UpdateIncidentOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if input.Incident == nil {
+		return nil, errors.New("Incident input is required")
+	}
+	if input.IncidentID == nil {
+		return nil, errors.New("Incident id is required")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.incidents[*input.IncidentID]; !ok {
+		return nil, notFoundError("incident", *input.IncidentID)
+	}
+	stored := *input.Incident
+	stored.ID = *input.IncidentID
+	f.incidents[stored.ID] = &stored
+	result := stored
+
+	return &ilert.UpdateIncidentOutput{Incident: &result}, nil
+}
+
+// CreateConnector stores a new connector with a generated id
+func (f *FakeClient) CreateConnector(input *ilert.CreateConnectorInput) (*ilert.CreateConnectorOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if input.Connector == nil {
+		return nil, errors.New("Connector input is required")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	connector, err := toConnectorOutput(strconv.FormatInt(f.newID(), 10), input.Connector)
+	if err != nil {
+		return nil, err
+	}
+	f.connectors[connector.ID] = connector
+	result := *connector
+
+	return &ilert.CreateConnectorOutput{Connector: &result}, nil
+}
+
+// GetConnector gets the stored connector with specified id
+func (f *FakeClient) GetConnector(input *ilert.GetConnectorInput) (*ilert.GetConnectorOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if input.ConnectorID == nil {
+		return nil, errors.New("Connector id is required")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	connector, ok := f.connectors[*input.ConnectorID]
+	if !ok {
+		return nil, notFoundError("connector", *input.ConnectorID)
+	}
+	result := *connector
+
+	return &ilert.GetConnectorOutput{Connector: &result}, nil
+}
+
+// ConnectorExists checks if a connector with specified id is stored
+func (f *FakeClient) ConnectorExists(id string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.connectors[id]
+	return ok, nil
+}
+
+// GetConnectors lists the stored connectors ordered by id
+func (f *FakeClient) GetConnectors(input *ilert.GetConnectorsInput) (*ilert.GetConnectorsOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	connectors := make([]*ilert.ConnectorOutput, 0, len(f.connectors))
+	for _, connector := range f.connectors {
+		result := *connector
+		connectors = append(connectors, &result)
+	}
+	sort.Slice(connectors, func(i, j int) bool {
+		return connectors[i].ID < connectors[j].ID
+	})
+
+	return &ilert.GetConnectorsOutput{Connectors: connectors}, nil
+}
+
+// UpdateConnector replaces the stored connector with specified id
+func (f *FakeClient) UpdateConnector(input *ilert.UpdateConnectorInput) (*ilert.UpdateConnectorOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if input.Connector == nil {
+		return nil, errors.New("Connector input is required")
+	}
+	if input.ConnectorID == nil {
+		return nil, errors.New("Connector id is required")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.connectors[*input.ConnectorID]; !ok {
+		return nil, notFoundError("connector", *input.ConnectorID)
+	}
+	connector, err := toConnectorOutput(*input.ConnectorID, input.Connector)
+	if err != nil {
+		return nil, err
+	}
+	f.connectors[connector.ID] = connector
+	result := *connector
+
+	return &ilert.UpdateConnectorOutput{Connector: &result}, nil
+}
+
+// DeleteConnector removes the stored connector with specified id
+func (f *FakeClient) DeleteConnector(input *ilert.DeleteConnectorInput) (*ilert.DeleteConnectorOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if input.ConnectorID == nil {
+		return nil, errors.New("Connector id is required")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.connectors[*input.ConnectorID]; !ok {
+		return nil, notFoundError("connector", *input.ConnectorID)
+	}
+	delete(f.connectors, *input.ConnectorID)
+
+	return &ilert.DeleteConnectorOutput{}, nil
+}
+
+// toConnectorOutput converts the connector payload into the representation returned by the API
+func toConnectorOutput(id string, connector *ilert.Connector) (*ilert.ConnectorOutput, error) {
+	output := &ilert.ConnectorOutput{
+		ID:        id,
+		Name:      connector.Name,
+		Type:      connector.Type,
+		CreatedAt: connector.CreatedAt,
+		UpdatedAt: connector.UpdatedAt,
+	}
+	if connector.Params != nil {
+		data, err := json.Marshal(connector.Params)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal(data, &output.Params)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return output, nil
+}
+
+// CreateEscalationPolicy stores a new escalation policy with a generated id
+func (f *FakeClient) CreateEscalationPolicy(input *ilert.CreateEscalationPolicyInput) (*ilert.CreateEscalationPolicyOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if input.EscalationPolicy == nil {
+		return nil, errors.New("escalation policy input is required")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored := *input.EscalationPolicy
+	stored.ID = f.newID()
+	f.escalationPolicies[stored.ID] = &stored
+	result := stored
+
+	return &ilert.CreateEscalationPolicyOutput{EscalationPolicy: &result}, nil
+}
+
+// GetEscalationPolicy gets the stored escalation policy with specified id
+func (f *FakeClient) GetEscalationPolicy(input *ilert.GetEscalationPolicyInput) (*ilert.GetEscalationPolicyOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if input.EscalationPolicyID == nil {
+		return nil, errors.New("escalation policy id is required")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	escalationPolicy, ok := f.escalationPolicies[*input.EscalationPolicyID]
+	if !ok {
+		return nil, notFoundError("escalation policy", *input.EscalationPolicyID)
+	}
+	result := *escalationPolicy
+
+	return &ilert.GetEscalationPolicyOutput{EscalationPolicy: &result}, nil
+}
+
+// EscalationPolicyExists checks if an escalation policy with specified id is stored
+func (f *FakeClient) EscalationPolicyExists(id int64) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.escalationPolicies[id]
+	return ok, nil
+}
+
+// GetEscalationPolicies lists the stored escalation policies ordered by id
+func (f *FakeClient) GetEscalationPolicies(input *ilert.GetEscalationPoliciesInput) (*ilert.GetEscalationPoliciesOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	escalationPolicies := make([]*ilert.EscalationPolicy, 0, len(f.escalationPolicies))
+	for _, escalationPolicy := range f.escalationPolicies {
+		result := *escalationPolicy
+		escalationPolicies = append(escalationPolicies, &result)
+	}
+	sort.Slice(escalationPolicies, func(i, j int) bool {
+		return escalationPolicies[i].ID < escalationPolicies[j].ID
+	})
+
+	return &ilert.GetEscalationPoliciesOutput{EscalationPolicies: escalationPolicies}, nil
+}
+
+// UpdateEscalationPolicy replaces the stored escalation policy with specified id, or applies the partial update
+func (f *FakeClient) UpdateEscalationPolicy(input *ilert.UpdateEscalationPolicyInput) (*ilert.UpdateEscalationPolicyOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if input.EscalationPolicy == nil && input.Update == nil {
+		return nil, errors.New("escalation policy input is required")
+	}
+	if input.EscalationPolicyID == nil {
+		return nil, errors.New("escalation policy id is required")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	existing, ok := f.escalationPolicies[*input.EscalationPolicyID]
+	if !ok {
+		return nil, notFoundError("escalation policy", *input.EscalationPolicyID)
+	}
+	var stored ilert.EscalationPolicy
+	if input.EscalationPolicy != nil {
+		stored = *input.EscalationPolicy
+	} else {
+		stored = applyEscalationPolicyUpdate(*existing, input.Update)
+	}
+	stored.ID = *input.EscalationPolicyID
+	f.escalationPolicies[stored.ID] = &stored
+	result := stored
+
+	return &ilert.UpdateEscalationPolicyOutput{EscalationPolicy: &result}, nil
+}
+
+// applyEscalationPolicyUpdate returns the escalation policy with the set fields of the update applied
+func applyEscalationPolicyUpdate(escalationPolicy ilert.EscalationPolicy, update *ilert.EscalationPolicyUpdate) ilert.EscalationPolicy {
+	if update.Name != nil {
+		escalationPolicy.Name = *update.Name
+	}
+	if update.EscalationRules != nil {
+		escalationPolicy.EscalationRules = update.EscalationRules
+	}
+	if update.Repeating != nil {
+		escalationPolicy.Repeating = *update.Repeating
+	}
+	if update.Frequency != nil {
+		escalationPolicy.Frequency = *update.Frequency
+	}
+	if update.Teams != nil {
+		escalationPolicy.Teams = update.Teams
+	}
+	return escalationPolicy
+}
+
+// DeleteEscalationPolicy removes the stored escalation policy with specified id
+func (f *FakeClient) DeleteEscalationPolicy(input *ilert.DeleteEscalationPolicyInput) (*ilert.DeleteEscalationPolicyOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if input.EscalationPolicyID == nil {
+		return nil, errors.New("escalation policy id is required")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.escalationPolicies[*input.EscalationPolicyID]; !ok {
+		return nil, notFoundError("escalation policy", *input.EscalationPolicyID)
+	}
+	delete(f.escalationPolicies, *input.EscalationPolicyID)
+
+	return &ilert.DeleteEscalationPolicyOutput{}, nil
+}
+
+func containsString(values []*string, value string) bool {
+	for _, v := range values {
+		if v != nil && *v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt64(values []*int64, value int64) bool {
+	for _, v := range values {
+		if v != nil && *v == value {
+			return true
+		}
+	}
+	return false
+}
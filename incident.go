@@ -1,34 +1,126 @@
 package ilert
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
 )
 
 // Incident definition
 type Incident struct {
-	ID                 int64                  `json:"id"`
-	Summary            string                 `json:"summary"`
-	Details            string                 `json:"details"`
-	ReportTime         string                 `json:"reportTime"` // Date time string in ISO format
-	ResolvedOn         string                 `json:"resolvedOn"` // Date time string in ISO format
-	Status             string                 `json:"status"`
-	AlertSource        *AlertSource           `json:"alertSource,omitempty"`
-	Priority           string                 `json:"priority"`
-	IncidentKey        string                 `json:"incidentKey"`
-	AssignedTo         *User                  `json:"assignedTo,omitempty"`
-	NextEscalation     string                 `json:"nextEscalation"` // Date time string in ISO format
-	CallRoutingNumber  *CallRoutingNumber     `json:"callRoutingNumber,omitempty"`
-	AcknowledgedBy     *User                  `json:"acknowledgedBy,omitempty"`
-	AcknowledgedByType string                 `json:"acknowledgedByType,omitempty"`
-	ResolvedBy         *User                  `json:"resolvedBy,omitempty"`
-	ResolvedByType     string                 `json:"resolvedByType,omitempty"`
-	Images             []IncidentImage        `json:"images,omitempty"`
-	Links              []IncidentLink         `json:"links,omitempty"`
-	CustomDetails      map[string]interface{} `json:"customDetails,omitempty"`
+	ID                 int64                 `json:"id"`
+	Summary            string                `json:"summary"`
+	Details            string                `json:"details"`
+	ReportTime         string                `json:"reportTime"` // Date time string in ISO format
+	ResolvedOn         string                `json:"resolvedOn"` // Date time string in ISO format
+	Status             string                `json:"status"`
+	AlertSource        *AlertSource          `json:"alertSource,omitempty"`
+	Priority           string                `json:"priority"`
+	IncidentKey        string                `json:"incidentKey"`
+	AssignedTo         *User                 `json:"assignedTo,omitempty"`
+	NextEscalation     string                `json:"nextEscalation"` // Date time string in ISO format
+	CallRoutingNumber  *CallRoutingNumber    `json:"callRoutingNumber,omitempty"`
+	AcknowledgedBy     *User                 `json:"acknowledgedBy,omitempty"`
+	AcknowledgedByType string                `json:"acknowledgedByType,omitempty"` // one of IncidentActorTypes
+	ResolvedBy         *User                 `json:"resolvedBy,omitempty"`
+	ResolvedByType     string                `json:"resolvedByType,omitempty"` // one of IncidentActorTypes
+	Images             []IncidentImage       `json:"images,omitempty"`
+	Links              []IncidentLink        `json:"links,omitempty"`
+	CustomDetails      IncidentCustomDetails `json:"customDetails,omitempty"`
+}
+
+// IncidentCustomDetails are the custom details of an incident. Numbers are decoded as json.Number
+// so large integers do not lose precision, see Incident.CustomDetailInt64
+type IncidentCustomDetails map[string]interface{}
+
+// UnmarshalJSON decodes the custom details, keeping numbers as json.Number
+func (d *IncidentCustomDetails) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = nil
+		return nil
+	}
+	details := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&details); err != nil {
+		return err
+	}
+	*d = details
+	return nil
+}
+
+// ErrCustomDetailNotFound is returned by the custom detail getters of an incident if the key is not set
+var ErrCustomDetailNotFound = errors.New("custom detail not found")
+
+// CustomDetailInt64 returns the custom detail with the given key as int64.
+// Fails if the value is not an integer number or out of range
+func (i *Incident) CustomDetailInt64(key string) (int64, error) {
+	value, ok := i.CustomDetails[key]
+	if !ok {
+		return 0, fmt.Errorf("%s: %w", key, ErrCustomDetailNotFound)
+	}
+	switch v := value.(type) {
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return 0, fmt.Errorf("custom detail %s: %w", key, err)
+		}
+		return n, nil
+	case float64:
+		if v != float64(int64(v)) {
+			return 0, fmt.Errorf("custom detail %s is not an integer: %v", key, v)
+		}
+		return int64(v), nil
+	}
+	return 0, fmt.Errorf("custom detail %s is not a number: %T", key, value)
+}
+
+// CustomDetailFloat64 returns the custom detail with the given key as float64
+func (i *Incident) CustomDetailFloat64(key string) (float64, error) {
+	value, ok := i.CustomDetails[key]
+	if !ok {
+		return 0, fmt.Errorf("%s: %w", key, ErrCustomDetailNotFound)
+	}
+	switch v := value.(type) {
+	case json.Number:
+		n, err := v.Float64()
+		if err != nil {
+			return 0, fmt.Errorf("custom detail %s: %w", key, err)
+		}
+		return n, nil
+	case float64:
+		return v, nil
+	}
+	return 0, fmt.Errorf("custom detail %s is not a number: %T", key, value)
+}
+
+// CustomDetailString returns the custom detail with the given key as string, numbers and booleans
+// are formatted as in the JSON payload
+func (i *Incident) CustomDetailString(key string) (string, error) {
+	value, ok := i.CustomDetails[key]
+	if !ok {
+		return "", fmt.Errorf("%s: %w", key, ErrCustomDetailNotFound)
+	}
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	return "", fmt.Errorf("custom detail %s is not a string: %T", key, value)
 }
 
 // IncidentImage represents event image
@@ -46,13 +138,13 @@ type IncidentLink struct {
 
 // IncidentComment definition
 type IncidentComment struct {
-	ID             string `json:"id"`
+	ID             string `json:"id,omitempty"`
 	Content        string `json:"content"`
-	Creator        *User  `json:"creator"`
-	TriggerType    string `json:"triggerType"`
+	Creator        *User  `json:"creator,omitempty"`
+	TriggerType    string `json:"triggerType,omitempty"`
 	ResolveComment bool   `json:"resolveComment"`
-	Created        string `json:"created"`
-	Updated        string `json:"updated"`
+	Created        string `json:"created,omitempty"`
+	Updated        string `json:"updated,omitempty"`
 }
 
 // CallRoutingNumber definition
@@ -94,6 +186,17 @@ var IncidentResponderTypes = struct {
 	AlertSource: "SOURCE",
 }
 
+// IncidentActorTypes defines the types of the actor that acknowledged or resolved an incident,
+// see Incident.AcknowledgedByType and Incident.ResolvedByType. Actors are responders, so these are the
+// IncidentResponderTypes
+var IncidentActorTypes = IncidentResponderTypes
+
+// IncidentActorTypesAll defines incident actor types list
+var IncidentActorTypesAll = []string{
+	IncidentActorTypes.User,
+	IncidentActorTypes.AlertSource,
+}
+
 // IncidentResponder definition
 type IncidentResponder struct {
 	ID       int64  `json:"id"`
@@ -115,6 +218,14 @@ var IncidentResponderGroups = struct {
 	OnCallSchedule:   "ON_CALL_SCHEDULE",
 }
 
+// IncidentResponderGroupsAll defines incident responder groups list
+var IncidentResponderGroupsAll = []string{
+	IncidentResponderGroups.Suggested,
+	IncidentResponderGroups.User,
+	IncidentResponderGroups.EscalationPolicy,
+	IncidentResponderGroups.OnCallSchedule,
+}
+
 // IncidentLogEntry definition
 type IncidentLogEntry struct {
 	ID           int64  `json:"id"`
@@ -164,6 +275,81 @@ type IncidentActionResult struct {
 	Success     bool   `json:"success"`
 }
 
+// ErrIncidentNotResolved is returned by TimeToResolve for incidents that are not resolved yet
+var ErrIncidentNotResolved = errors.New("incident is not resolved")
+
+// ErrIncidentNotAcknowledged is returned by TimeToAcknowledge for incidents no user has responded to yet
+var ErrIncidentNotAcknowledged = errors.New("incident is not acknowledged")
+
+// TimeToAcknowledge returns the time from the report of the incident until the first user response
+// (accept or resolve). The incident itself does not carry an acknowledgement time, so the log entries of the
+// incident (see GetIncidentLogEntries) are required. Returns ErrIncidentNotAcknowledged if there is no user response.
+// The timestamps are parsed with the known ISO 8601 layouts and then the additional layouts, the layouts of
+// WithTimeLayouts are not known to the incident and have to be passed again
+func (i *Incident) TimeToAcknowledge(logEntries []*IncidentLogEntry, additionalLayouts ...string) (time.Duration, error) {
+	reportTime, err := parseILERTTime(i.ReportTime, additionalLayouts...)
+	if err != nil {
+		return 0, fmt.Errorf("incident %d: invalid report time: %w", i.ID, err)
+	}
+
+	var acknowledged *time.Time
+	for _, logEntry := range logEntries {
+		if logEntry == nil || logEntry.LogEntryType != IncidentLogEntryTypes.UserResponseLogEntry {
+			continue
+		}
+		if logEntry.IncidentID != 0 && logEntry.IncidentID != i.ID {
+			continue
+		}
+		timestamp, err := parseILERTTime(logEntry.Timestamp, additionalLayouts...)
+		if err != nil {
+			return 0, fmt.Errorf("log entry %d: invalid timestamp: %w", logEntry.ID, err)
+		}
+		if acknowledged == nil || timestamp.Before(*acknowledged) {
+			acknowledged = &timestamp
+		}
+	}
+	if acknowledged == nil {
+		return 0, ErrIncidentNotAcknowledged
+	}
+
+	return acknowledged.Sub(reportTime), nil
+}
+
+// TimeToResolve returns the time from the report of the incident until it was resolved.
+// Returns ErrIncidentNotResolved for incidents that are still open. Additional layouts are used like in TimeToAcknowledge
+func (i *Incident) TimeToResolve(additionalLayouts ...string) (time.Duration, error) {
+	if i.Status != IncidentStatuses.Resolved || i.ResolvedOn == "" {
+		return 0, ErrIncidentNotResolved
+	}
+
+	reportTime, err := parseILERTTime(i.ReportTime, additionalLayouts...)
+	if err != nil {
+		return 0, fmt.Errorf("incident %d: invalid report time: %w", i.ID, err)
+	}
+	resolvedOn, err := parseILERTTime(i.ResolvedOn, additionalLayouts...)
+	if err != nil {
+		return 0, fmt.Errorf("incident %d: invalid resolved on time: %w", i.ID, err)
+	}
+
+	return resolvedOn.Sub(reportTime), nil
+}
+
+// WasAcknowledgedByUser reports whether the incident was acknowledged by a user
+func (i *Incident) WasAcknowledgedByUser() bool {
+	return i.AcknowledgedByType == IncidentActorTypes.User
+}
+
+// WasResolvedByUser reports whether the incident was resolved by a user
+func (i *Incident) WasResolvedByUser() bool {
+	return i.ResolvedByType == IncidentActorTypes.User
+}
+
+// WasResolvedBySystem reports whether the incident was resolved without a user, e.g. by a resolve event of its
+// alert source or an auto resolve timeout. Returns false for incidents that are not resolved
+func (i *Incident) WasResolvedBySystem() bool {
+	return i.ResolvedByType != "" && i.ResolvedByType != IncidentActorTypes.User
+}
+
 // GetIncidentInput represents the input of a GetIncident operation.
 type GetIncidentInput struct {
 	_          struct{}
@@ -178,6 +364,11 @@ type GetIncidentOutput struct {
 
 // GetIncident gets the incident with specified id. https://api.ilert.com/api-docs/#tag/Incidents/paths/~1incidents~1{id}/get
 func (c *Client) GetIncident(input *GetIncidentInput) (*GetIncidentOutput, error) {
+	return c.GetIncidentWithContext(context.Background(), input)
+}
+
+// GetIncidentWithContext gets the incident with specified id, the request is aborted when the context is cancelled.
+func (c *Client) GetIncidentWithContext(ctx context.Context, input *GetIncidentInput) (*GetIncidentOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
 	}
@@ -185,16 +376,16 @@ func (c *Client) GetIncident(input *GetIncidentInput) (*GetIncidentOutput, error
 		return nil, errors.New("Incident id is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d", apiRoutes.incidents, *input.IncidentID))
+	resp, err := c.newRequestWithContext(ctx).Get(fmt.Sprintf("%s/%d", apiRoutes.incidents, *input.IncidentID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	incident := &Incident{}
-	err = json.Unmarshal(resp.Body(), incident)
+	err = decodeResponse("GetIncident", resp, incident)
 	if err != nil {
 		return nil, err
 	}
@@ -202,13 +393,133 @@ func (c *Client) GetIncident(input *GetIncidentInput) (*GetIncidentOutput, error
 	return &GetIncidentOutput{Incident: incident}, nil
 }
 
+// GetIncidentIfModifiedOutput represents the output of a GetIncidentIfModified operation.
+type GetIncidentIfModifiedOutput struct {
+	_ struct{}
+
+	// true if the server answered 304 Not Modified, Incident is nil in this case
+	NotModified bool
+	Incident    *Incident
+}
+
+// GetIncidentIfModified gets the incident with specified id only if it changed after since, e.g. the report time
+// or the time of the last poll. The If-Modified-Since header is sent and a 304 response is reported as NotModified.
+// Servers that ignore the header answer with the full incident, which is returned like in GetIncident
+func (c *Client) GetIncidentIfModified(input *GetIncidentInput, since time.Time) (*GetIncidentIfModifiedOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if input.IncidentID == nil {
+		return nil, errors.New("Incident id is required")
+	}
+
+	resp, err := c.newRequest().
+		SetHeader("If-Modified-Since", since.UTC().Format(http.TimeFormat)).
+		Get(fmt.Sprintf("%s/%d", apiRoutes.incidents, *input.IncidentID))
+	if err != nil {
+		return nil, err
+	}
+	if apiErr := c.getGenericAPIError(resp, 200, 304); apiErr != nil {
+		return nil, apiErr
+	}
+	if resp.StatusCode() == 304 {
+		return &GetIncidentIfModifiedOutput{NotModified: true}, nil
+	}
+
+	incident := &Incident{}
+	err = decodeResponse("GetIncidentIfModified", resp, incident)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetIncidentIfModifiedOutput{Incident: incident}, nil
+}
+
+// GetIncidentInto gets the incident with specified id like GetIncident, but unmarshals the response into out,
+// which must be a non-nil pointer, e.g. to a custom incident type with additional fields
+func (c *Client) GetIncidentInto(input *GetIncidentInput, out interface{}) error {
+	if input == nil {
+		return errors.New("input is required")
+	}
+	if input.IncidentID == nil {
+		return errors.New("Incident id is required")
+	}
+	if err := validateOutput(out); err != nil {
+		return err
+	}
+
+	resp, err := c.newRequest().Get(fmt.Sprintf("%s/%d", apiRoutes.incidents, *input.IncidentID))
+	if err != nil {
+		return err
+	}
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
+		return apiErr
+	}
+
+	return decodeResponse("GetIncidentInto", resp, out)
+}
+
+// IncidentExists checks if the incident with specified id exists
+func (c *Client) IncidentExists(id int64) (bool, error) {
+	_, err := c.GetIncident(&GetIncidentInput{IncidentID: Int64(id)})
+	if err != nil {
+		if IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// WaitForIncidentStatus polls the incident with specified id every pollInterval until it has the target status
+// (one of IncidentStatuses) or the context is cancelled. A resolved incident never changes its status again,
+// so waiting for any other status of a resolved incident returns an error.
+func (c *Client) WaitForIncidentStatus(ctx context.Context, incidentID int64, target string, pollInterval time.Duration) (*Incident, error) {
+	if target != IncidentStatuses.New && target != IncidentStatuses.Pending && target != IncidentStatuses.Accepted && target != IncidentStatuses.Resolved {
+		return nil, fmt.Errorf("invalid incident status %q", target)
+	}
+	if pollInterval <= 0 {
+		return nil, errors.New("poll interval must be positive")
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		output, err := c.GetIncidentWithContext(ctx, &GetIncidentInput{IncidentID: Int64(incidentID)})
+		if err != nil {
+			return nil, err
+		}
+		if output.Incident.Status == target {
+			return output.Incident, nil
+		}
+		if output.Incident.Status == IncidentStatuses.Resolved {
+			return output.Incident, fmt.Errorf("incident %d is resolved and will not reach status %s", incidentID, target)
+		}
+
+		select {
+		case <-ctx.Done():
+			return output.Incident, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
 // GetIncidentsInput represents the input of a GetIncidents operation.
+// Multiple values of a filter are sent as repeated query params and match incidents with any of the values,
+// different filters must all match, e.g. States NEW and PENDING with AlertSources 1 lists the new or pending
+// incidents of alert source 1.
+// Note: the API does not support filtering by call routing number, use Incident.CallRoutingNumber of the returned incidents instead.
+// The API does not support filtering by priority either, use Incident.Priority of the returned incidents instead.
 type GetIncidentsInput struct {
 	_ struct{}
 	// an integer specifying the starting point (beginning with 0) when paging through a list of entities
 	StartIndex *int
 
 	// the maximum number of results when paging through a list of entities.
+	// When nil the API applies its default of DefaultMaxResults, so the result is silently truncated
+	// if more incidents match. Page with StartIndex to get all incidents.
+	// Values above MaxPageSize are clamped to it.
 	// Default: 50
 	MaxResults *int
 
@@ -221,7 +532,9 @@ type GetIncidentsInput struct {
 	// user IDs of the user that the incident is assigned to
 	AssignedToUserIDs []*int64
 
-	// usernames of the user that the incident is assigned to
+	// usernames of the user that the incident is assigned to.
+	// Ids and usernames are combined into a single assigned-to filter that matches incidents assigned to any of
+	// the given users, so passing the id and the username of the same user does not return an incident twice
 	AssignedToUserNames []*string
 
 	// Date time string in ISO format
@@ -229,6 +542,9 @@ type GetIncidentsInput struct {
 
 	// Date time string in ISO format
 	Until *string
+
+	// IDs of the teams the incidents belong to, see WithDefaultTeam
+	TeamIDs []*int64
 }
 
 // GetIncidentsOutput represents the output of a GetIncidents operation.
@@ -243,6 +559,50 @@ func (c *Client) GetIncidents(input *GetIncidentsInput) (*GetIncidentsOutput, er
 		input = &GetIncidentsInput{}
 	}
 
+	resp, err := c.newRequest().Get(fmt.Sprintf("%s?%s", apiRoutes.incidents, c.prepareIncidentsInput(input).toQuery().Encode()))
+	if err != nil {
+		return nil, err
+	}
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
+		return nil, apiErr
+	}
+
+	incidents := make([]*Incident, 0)
+	err = decodeResponse("GetIncidents", resp, &incidents)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.MaxResults == nil && len(incidents) == DefaultMaxResults && c.logger != nil {
+		c.logger.Warnf("GetIncidents returned %d incidents, the default page size of the API, the result is probably truncated. Set StartIndex and MaxResults to page through all incidents", len(incidents))
+	}
+
+	return &GetIncidentsOutput{Incidents: incidents}, nil
+}
+
+// GetIncidentsInto lists incidents like GetIncidents, but unmarshals the response into out, which must be
+// a non-nil pointer, e.g. to a slice of a custom incident type with additional fields
+func (c *Client) GetIncidentsInto(input *GetIncidentsInput, out interface{}) error {
+	if err := validateOutput(out); err != nil {
+		return err
+	}
+	if input == nil {
+		input = &GetIncidentsInput{}
+	}
+
+	resp, err := c.newRequest().Get(fmt.Sprintf("%s?%s", apiRoutes.incidents, c.prepareIncidentsInput(input).toQuery().Encode()))
+	if err != nil {
+		return err
+	}
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
+		return apiErr
+	}
+
+	return decodeResponse("GetIncidentsInto", resp, out)
+}
+
+// toQuery encodes the filters and paging of a GetIncidents operation as query params
+func (input *GetIncidentsInput) toQuery() url.Values {
 	q := url.Values{}
 	if input.StartIndex != nil {
 		q.Add("start-index", strconv.Itoa(*input.StartIndex))
@@ -254,7 +614,7 @@ func (c *Client) GetIncidents(input *GetIncidentsInput) (*GetIncidentsOutput, er
 		q.Add("from", *input.From)
 	}
 	if input.Until != nil {
-		q.Add("until", *input.From)
+		q.Add("until", *input.Until)
 	}
 
 	for _, state := range input.States {
@@ -265,29 +625,233 @@ func (c *Client) GetIncidents(input *GetIncidentsInput) (*GetIncidentsOutput, er
 		q.Add("alert-source", strconv.FormatInt(*alertSourceID, 10))
 	}
 
-	for _, userID := range input.AssignedToUserIDs {
-		q.Add("assigned-to", strconv.FormatInt(*userID, 10))
+	addAssignedToQuery(q, input.AssignedToUserIDs, input.AssignedToUserNames)
+
+	for _, teamID := range input.TeamIDs {
+		if teamID != nil {
+			q.Add("team", strconv.FormatInt(*teamID, 10))
+		}
+	}
+
+	return q
+}
+
+// prepareIncidentsInput applies the default team of the client (see WithDefaultTeam) and MaxPageSize to the input
+func (c *Client) prepareIncidentsInput(input *GetIncidentsInput) *GetIncidentsInput {
+	maxResults := c.clampPageSize("GetIncidents", input.MaxResults)
+	if (input.TeamIDs != nil || c.defaultTeamID == nil) && maxResults == input.MaxResults {
+		return input
+	}
+	prepared := *input
+	prepared.TeamIDs = c.teamIDsOrDefault(input.TeamIDs)
+	prepared.MaxResults = maxResults
+	return &prepared
+}
+
+// addAssignedToQuery adds the user ids and usernames as assigned-to query params, skipping duplicate values.
+// The API matches incidents assigned to any of the given users, so ids and usernames can be mixed and a user given
+// by both id and username is not a problem; only identical values are deduplicated as ids and usernames can not be
+// related without looking up the users
+func addAssignedToQuery(q url.Values, userIDs []*int64, usernames []*string) {
+	seen := make(map[string]bool)
+	add := func(value string) {
+		if seen[value] {
+			return
+		}
+		seen[value] = true
+		q.Add("assigned-to", value)
+	}
+
+	for _, userID := range userIDs {
+		if userID != nil {
+			add(strconv.FormatInt(*userID, 10))
+		}
 	}
 
-	for _, username := range input.AssignedToUserNames {
-		q.Add("assigned-to", *username)
+	for _, username := range usernames {
+		if username != nil && *username != "" {
+			add(*username)
+		}
 	}
+}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s?%s", apiRoutes.incidents, q.Encode()))
+// GetIncidentsAssignedToMe lists incidents assigned to the currently authenticated user.
+// The assignee filters of the input are replaced by the current user id, the other filters are kept
+func (c *Client) GetIncidentsAssignedToMe(input *GetIncidentsInput) (*GetIncidentsOutput, error) {
+	userID, err := c.getCurrentUserID()
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
-		return nil, apiErr
+
+	filtered := GetIncidentsInput{}
+	if input != nil {
+		filtered = *input
 	}
+	filtered.AssignedToUserIDs = []*int64{Int64(userID)}
+	filtered.AssignedToUserNames = nil
 
+	return c.GetIncidents(&filtered)
+}
+
+// forEachIncidentsPage lists all incidents matching the input filters page by page and calls fn for each non-empty
+// page. Paging stops at the first page with fewer than MaxResults incidents, which must be at least 1
+func (c *Client) forEachIncidentsPage(input *GetIncidentsInput, fn func(incidents []*Incident) error) error {
+	page := GetIncidentsInput{}
+	if input != nil {
+		page = *input
+	}
+	if page.StartIndex == nil {
+		page.StartIndex = Int(0)
+	}
+	if page.MaxResults == nil {
+		page.MaxResults = Int(DefaultMaxResults)
+	}
+	if *page.MaxResults < 1 {
+		return errors.New("max results must be at least 1")
+	}
+	// a larger page size would be clamped by the server and end the paging after the first page
+	page.MaxResults = c.clampPageSize("GetIncidents", page.MaxResults)
+
+	for {
+		output, err := c.GetIncidents(&page)
+		if err != nil {
+			return err
+		}
+		if len(output.Incidents) == 0 {
+			return nil
+		}
+		if err := fn(output.Incidents); err != nil {
+			return err
+		}
+		if len(output.Incidents) < *page.MaxResults {
+			return nil
+		}
+		page.StartIndex = Int(*page.StartIndex + len(output.Incidents))
+	}
+}
+
+// GetIncidentsPaged lists all incidents matching the filters of the input by requesting pages of MaxResults
+// incidents (default DefaultMaxResults), starting at StartIndex, until a page has fewer incidents.
+// See GetAllIncidents to also verify the result against the incident count
+func (c *Client) GetIncidentsPaged(input *GetIncidentsInput) ([]*Incident, error) {
 	incidents := make([]*Incident, 0)
-	err = json.Unmarshal(resp.Body(), &incidents)
+	err := c.forEachIncidentsPage(input, func(page []*Incident) error {
+		incidents = append(incidents, page...)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &GetIncidentsOutput{Incidents: incidents}, nil
+	return incidents, nil
+}
+
+// SearchIncidentsByCustomDetail lists the incidents matching the filters of the input whose custom detail key has
+// the value, e.g. a Kubernetes pod name. The API can not filter by custom details, so all matching incidents are
+// paged through like in GetIncidentsPaged and compared client side; numbers and booleans are compared in their JSON
+// form. Narrow the input filters, e.g. by state or alert source, to reduce the number of requests
+func (c *Client) SearchIncidentsByCustomDetail(key, value string, input *GetIncidentsInput) ([]*Incident, error) {
+	if key == "" {
+		return nil, errors.New("custom detail key is required")
+	}
+
+	matches := make([]*Incident, 0)
+	err := c.forEachIncidentsPage(input, func(incidents []*Incident) error {
+		for _, incident := range incidents {
+			if detail, err := incident.CustomDetailString(key); err == nil && detail == value {
+				matches = append(matches, incident)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+// GetAllIncidentsInput represents the input of a GetAllIncidents operation.
+type GetAllIncidentsInput struct {
+	_ struct{}
+
+	// filters of the listed incidents, StartIndex and MaxResults control the paging
+	Filter *GetIncidentsInput
+
+	// (optional) compare the number of fetched incidents with GetIncidentsCount using the same filters
+	VerifyCount bool
+
+	// the allowed difference between the number of fetched incidents and the count, e.g. for incidents created
+	// while paging. Default: 0
+	CountTolerance int
+}
+
+// GetAllIncidentsOutput represents the output of a GetAllIncidents operation.
+type GetAllIncidentsOutput struct {
+	_         struct{}
+	Incidents []*Incident
+}
+
+// IncidentsCountMismatchError is returned by GetAllIncidents with VerifyCount when the number of fetched
+// incidents differs from the count endpoint by more than the tolerance
+type IncidentsCountMismatchError struct {
+	Fetched   int
+	Count     int
+	Tolerance int
+}
+
+func (e *IncidentsCountMismatchError) Error() string {
+	return fmt.Sprintf("fetched %d incidents but the count is %d (tolerance %d)", e.Fetched, e.Count, e.Tolerance)
+}
+
+// GetAllIncidents lists all incidents matching the filters by paging through the results.
+// With VerifyCount the result is cross-checked against GetIncidentsCount, on a mismatch the fetched incidents
+// are returned together with an *IncidentsCountMismatchError
+func (c *Client) GetAllIncidents(input *GetAllIncidentsInput) (*GetAllIncidentsOutput, error) {
+	if input == nil {
+		input = &GetAllIncidentsInput{}
+	}
+	filter := &GetIncidentsInput{}
+	if input.Filter != nil {
+		filter = input.Filter
+	}
+
+	incidents, err := c.GetIncidentsPaged(filter)
+	if err != nil {
+		return nil, err
+	}
+	output := &GetAllIncidentsOutput{Incidents: incidents}
+
+	if !input.VerifyCount {
+		return output, nil
+	}
+
+	countOutput, err := c.GetIncidentsCount(&GetIncidentsCountInput{
+		States:              filter.States,
+		AlertSources:        filter.AlertSources,
+		AssignedToUserIDs:   filter.AssignedToUserIDs,
+		AssignedToUserNames: filter.AssignedToUserNames,
+		From:                filter.From,
+		Until:               filter.Until,
+		TeamIDs:             filter.TeamIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	count := countOutput.Count
+	if filter.StartIndex != nil {
+		count -= *filter.StartIndex
+	}
+	diff := count - len(incidents)
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff > input.CountTolerance {
+		return output, &IncidentsCountMismatchError{Fetched: len(incidents), Count: count, Tolerance: input.CountTolerance}
+	}
+
+	return output, nil
 }
 
 // GetIncidentsCountInput represents the input of a GetIncidentsCount operation.
@@ -303,7 +867,9 @@ type GetIncidentsCountInput struct {
 	// user IDs of the user that the incident is assigned to
 	AssignedToUserIDs []*int64
 
-	// usernames of the user that the incident is assigned to
+	// usernames of the user that the incident is assigned to.
+	// Ids and usernames are combined into a single assigned-to filter that matches incidents assigned to any of
+	// the given users, so passing the id and the username of the same user does not return an incident twice
 	AssignedToUserNames []*string
 
 	// Date time string in ISO format
@@ -311,6 +877,9 @@ type GetIncidentsCountInput struct {
 
 	// Date time string in ISO format
 	Until *string
+
+	// IDs of the teams the incidents belong to, see WithDefaultTeam
+	TeamIDs []*int64
 }
 
 // GetIncidentsCountOutput represents the output of a GetIncidentsCount operation.
@@ -319,18 +888,47 @@ type GetIncidentsCountOutput struct {
 	Count int
 }
 
-// GetIncidentsCount gets list uptime monitors. https://api.ilert.com/api-docs/#tag/Incidents/paths/~1incidents~1count/get
+// GetIncidentsCount gets the number of incidents. https://api.ilert.com/api-docs/#tag/Incidents/paths/~1incidents~1count/get
 func (c *Client) GetIncidentsCount(input *GetIncidentsCountInput) (*GetIncidentsCountOutput, error) {
+	return c.GetIncidentsCountWithContext(context.Background(), input)
+}
+
+// GetIncidentsCountWithContext gets the number of incidents, the request is aborted when the context is cancelled.
+func (c *Client) GetIncidentsCountWithContext(ctx context.Context, input *GetIncidentsCountInput) (*GetIncidentsCountOutput, error) {
 	if input == nil {
 		input = &GetIncidentsCountInput{}
 	}
+	if input.TeamIDs == nil && c.defaultTeamID != nil {
+		scoped := *input
+		scoped.TeamIDs = c.teamIDsOrDefault(nil)
+		input = &scoped
+	}
+
+	resp, err := c.newRequestWithContext(ctx).Get(fmt.Sprintf("%s/count?%s", apiRoutes.incidents, input.toQuery().Encode()))
+	if err != nil {
+		return nil, err
+	}
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
+		return nil, apiErr
+	}
+
+	body := &GenericCountResponse{}
+	err = decodeResponse("GetIncidentsCount", resp, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetIncidentsCountOutput{Count: body.Count}, nil
+}
 
+// toQuery encodes the filters of a GetIncidentsCount operation as query params
+func (input *GetIncidentsCountInput) toQuery() url.Values {
 	q := url.Values{}
 	if input.From != nil {
 		q.Add("from", *input.From)
 	}
 	if input.Until != nil {
-		q.Add("until", *input.From)
+		q.Add("until", *input.Until)
 	}
 
 	for _, state := range input.States {
@@ -341,36 +939,49 @@ func (c *Client) GetIncidentsCount(input *GetIncidentsCountInput) (*GetIncidents
 		q.Add("alert-source", strconv.FormatInt(*alertSourceID, 10))
 	}
 
-	for _, userID := range input.AssignedToUserIDs {
-		q.Add("assigned-to", strconv.FormatInt(*userID, 10))
-	}
+	addAssignedToQuery(q, input.AssignedToUserIDs, input.AssignedToUserNames)
 
-	for _, username := range input.AssignedToUserNames {
-		q.Add("assigned-to", *username)
+	for _, teamID := range input.TeamIDs {
+		if teamID != nil {
+			q.Add("team", strconv.FormatInt(*teamID, 10))
+		}
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/count?%s", apiRoutes.incidents, q.Encode()))
-	if err != nil {
-		return nil, err
-	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
-		return nil, apiErr
-	}
+	return q
+}
 
-	body := &GenericCountResponse{}
-	err = json.Unmarshal(resp.Body(), body)
+// OpenIncidentCount gets the number of incidents that are not resolved yet
+func (c *Client) OpenIncidentCount(ctx context.Context) (int, error) {
+	output, err := c.GetIncidentsCountWithContext(ctx, &GetIncidentsCountInput{
+		States: []*string{
+			String(IncidentStatuses.New),
+			String(IncidentStatuses.Pending),
+			String(IncidentStatuses.Accepted),
+		},
+	})
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	return &GetIncidentsCountOutput{Count: body.Count}, nil
+	return output.Count, nil
 }
 
 // GetIncidentResponderInput represents the input of a GetIncidentResponder operation.
+// Note: the responder suggestions do not depend on the incident priority, the API accepts no priority hint
+// The API returns all responders at once, Groups, StartIndex and MaxResults are applied client side.
 type GetIncidentResponderInput struct {
 	_          struct{}
 	IncidentID *int64
 	Language   *string
+
+	// (optional) only return responders of these groups, see IncidentResponderGroups
+	Groups []*string
+
+	// (optional) an integer specifying the starting point (beginning with 0) in the list of responders
+	StartIndex *int
+
+	// (optional) the maximum number of responders
+	MaxResults *int
 }
 
 // GetIncidentResponderOutput represents the output of a GetIncidentResponder operation.
@@ -387,6 +998,17 @@ func (c *Client) GetIncidentResponder(input *GetIncidentResponderInput) (*GetInc
 	if input.IncidentID == nil {
 		return nil, errors.New("Incident id is required")
 	}
+	for _, group := range input.Groups {
+		if group == nil || !stringSliceContains(IncidentResponderGroupsAll, *group) {
+			return nil, fmt.Errorf("invalid responder group, must be one of %s", strings.Join(IncidentResponderGroupsAll, ", "))
+		}
+	}
+	if input.StartIndex != nil && *input.StartIndex < 0 {
+		return nil, errors.New("start index must not be negative")
+	}
+	if input.MaxResults != nil && *input.MaxResults < 0 {
+		return nil, errors.New("max results must not be negative")
+	}
 
 	q := url.Values{}
 	if input.Language != nil {
@@ -397,20 +1019,43 @@ func (c *Client) GetIncidentResponder(input *GetIncidentResponderInput) (*GetInc
 		}
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d/responder", apiRoutes.incidents, *input.IncidentID))
+	resp, err := c.newRequest().Get(fmt.Sprintf("%s/%d/responder", apiRoutes.incidents, *input.IncidentID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	incidentResponders := make([]*IncidentResponder, 0)
-	err = json.Unmarshal(resp.Body(), &incidentResponders)
+	err = decodeResponse("GetIncidentResponder", resp, &incidentResponders)
 	if err != nil {
 		return nil, err
 	}
 
+	if len(input.Groups) > 0 {
+		filtered := make([]*IncidentResponder, 0, len(incidentResponders))
+		for _, responder := range incidentResponders {
+			for _, group := range input.Groups {
+				if responder.Group == *group {
+					filtered = append(filtered, responder)
+					break
+				}
+			}
+		}
+		incidentResponders = filtered
+	}
+	if input.StartIndex != nil {
+		if *input.StartIndex >= len(incidentResponders) {
+			incidentResponders = incidentResponders[:0]
+		} else {
+			incidentResponders = incidentResponders[*input.StartIndex:]
+		}
+	}
+	if input.MaxResults != nil && *input.MaxResults < len(incidentResponders) {
+		incidentResponders = incidentResponders[:*input.MaxResults]
+	}
+
 	return &GetIncidentResponderOutput{Responders: incidentResponders}, nil
 }
 
@@ -430,7 +1075,51 @@ type AssignIncidentOutput struct {
 	Incident *Incident
 }
 
+// validateAssignIncidentTargets checks that the assignment targets exist, so stale ids fail with
+// e.g. "user 123: not found" instead of an opaque server error
+func (c *Client) validateAssignIncidentTargets(input *AssignIncidentInput) error {
+	if input.UserID != nil {
+		_, err := c.GetUser(&GetUserInput{UserID: input.UserID})
+		if IsNotFound(err) {
+			return fmt.Errorf("user %d: %w", *input.UserID, ErrNotFound)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if input.Username != nil {
+		_, err := c.GetUser(&GetUserInput{Username: input.Username})
+		if IsNotFound(err) {
+			return fmt.Errorf("user %s: %w", *input.Username, ErrNotFound)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if input.EscalationPolicyID != nil {
+		exists, err := c.EscalationPolicyExists(*input.EscalationPolicyID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("escalation policy %d: %w", *input.EscalationPolicyID, ErrNotFound)
+		}
+	}
+	if input.ScheduleID != nil {
+		_, err := c.GetSchedule(&GetScheduleInput{ScheduleID: input.ScheduleID})
+		if IsNotFound(err) {
+			return fmt.Errorf("schedule %d: %w", *input.ScheduleID, ErrNotFound)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // AssignIncident gets the alert source with specified id. https://api.ilert.com/api-docs/#tag/Incidents/paths/~1incidents~1{id}~1assign/put
+// In strict mode the user, escalation policy or schedule is looked up first and a missing one is reported as ErrNotFound
 func (c *Client) AssignIncident(input *AssignIncidentInput) (*AssignIncidentOutput, error) {
 	if input == nil {
 		return nil, errors.New("input is required")
@@ -442,6 +1131,11 @@ func (c *Client) AssignIncident(input *AssignIncidentInput) (*AssignIncidentOutp
 	if input.UserID == nil && input.Username == nil && input.EscalationPolicyID == nil && input.ScheduleID == nil {
 		return nil, errors.New("one of assignments is required")
 	}
+	if c.strictMode {
+		if err := c.validateAssignIncidentTargets(input); err != nil {
+			return nil, err
+		}
+	}
 
 	q := url.Values{}
 	if input.UserID != nil {
@@ -457,16 +1151,16 @@ func (c *Client) AssignIncident(input *AssignIncidentInput) (*AssignIncidentOutp
 		q.Add("schedule-id", strconv.FormatInt(*input.ScheduleID, 10))
 	}
 
-	resp, err := c.httpClient.R().Put(fmt.Sprintf("%s/%d/assign?%s", apiRoutes.incidents, *input.IncidentID, q.Encode()))
+	resp, err := c.newRequest().Put(fmt.Sprintf("%s/%d/assign?%s", apiRoutes.incidents, *input.IncidentID, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	incident := &Incident{}
-	err = json.Unmarshal(resp.Body(), incident)
+	err = decodeResponse("AssignIncident", resp, incident)
 	if err != nil {
 		return nil, err
 	}
@@ -495,16 +1189,15 @@ func (c *Client) AcceptIncident(input *AcceptIncidentInput) (*AcceptIncidentOutp
 		return nil, errors.New("Incident id is required")
 	}
 
-	resp, err := c.httpClient.R().Put(fmt.Sprintf("%s/%d/accept", apiRoutes.incidents, *input.IncidentID))
+	resp, err := c.newRequest().Put(fmt.Sprintf("%s/%d/accept", apiRoutes.incidents, *input.IncidentID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200, 204); apiErr != nil {
 		return nil, apiErr
 	}
 
-	incident := &Incident{}
-	err = json.Unmarshal(resp.Body(), incident)
+	incident, err := getIncidentActionResult("AcceptIncident", resp, *input.IncidentID, IncidentStatuses.Accepted)
 	if err != nil {
 		return nil, err
 	}
@@ -533,16 +1226,15 @@ func (c *Client) ResolveIncident(input *ResolveIncidentInput) (*ResolveIncidentO
 		return nil, errors.New("Incident id is required")
 	}
 
-	resp, err := c.httpClient.R().Put(fmt.Sprintf("%s/%d/resolve", apiRoutes.incidents, *input.IncidentID))
+	resp, err := c.newRequest().Put(fmt.Sprintf("%s/%d/resolve", apiRoutes.incidents, *input.IncidentID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200, 204); apiErr != nil {
 		return nil, apiErr
 	}
 
-	incident := &Incident{}
-	err = json.Unmarshal(resp.Body(), incident)
+	incident, err := getIncidentActionResult("ResolveIncident", resp, *input.IncidentID, IncidentStatuses.Resolved)
 	if err != nil {
 		return nil, err
 	}
@@ -550,6 +1242,363 @@ func (c *Client) ResolveIncident(input *ResolveIncidentInput) (*ResolveIncidentO
 	return &ResolveIncidentOutput{Incident: incident}, nil
 }
 
+// IncidentKeyMultipleMatchesError is returned by AcceptIncidentByKey when more than one open incident has the key
+type IncidentKeyMultipleMatchesError struct {
+	IncidentKey string
+	IncidentIDs []int64
+}
+
+func (e *IncidentKeyMultipleMatchesError) Error() string {
+	ids := make([]string, 0, len(e.IncidentIDs))
+	for _, id := range e.IncidentIDs {
+		ids = append(ids, strconv.FormatInt(id, 10))
+	}
+	return fmt.Sprintf("incident key %q: %s: %s", e.IncidentKey, ErrMultipleMatches, strings.Join(ids, ", "))
+}
+
+// Unwrap allows errors.Is(err, ErrMultipleMatches)
+func (e *IncidentKeyMultipleMatchesError) Unwrap() error {
+	return ErrMultipleMatches
+}
+
+// AcceptIncidentByKey accepts the open incident with the given incident key, e.g. for chat ops where only the
+// key is known. The API can not filter by incident key, so all open incidents are listed and matched client side.
+// Returns ErrNotFound if no open incident has the key and a *IncidentKeyMultipleMatchesError if more than one has
+func (c *Client) AcceptIncidentByKey(incidentKey string) (*Incident, error) {
+	if incidentKey == "" {
+		return nil, errors.New("incident key is required")
+	}
+
+	matches := make([]*Incident, 0)
+	err := c.forEachIncidentsPage(&GetIncidentsInput{
+		States: []*string{
+			String(IncidentStatuses.New),
+			String(IncidentStatuses.Pending),
+			String(IncidentStatuses.Accepted),
+		},
+	}, func(incidents []*Incident) error {
+		for _, incident := range incidents {
+			if incident.IncidentKey == incidentKey {
+				matches = append(matches, incident)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("incident key %q: %w", incidentKey, ErrNotFound)
+	case 1:
+	default:
+		ids := make([]int64, 0, len(matches))
+		for _, incident := range matches {
+			ids = append(ids, incident.ID)
+		}
+		return nil, &IncidentKeyMultipleMatchesError{IncidentKey: incidentKey, IncidentIDs: ids}
+	}
+
+	output, err := c.AcceptIncident(&AcceptIncidentInput{IncidentID: Int64(matches[0].ID)})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Incident, nil
+}
+
+// TakeIncidentInput represents the input of a TakeIncident operation.
+type TakeIncidentInput struct {
+	_          struct{}
+	IncidentID *int64
+}
+
+// TakeIncidentOutput represents the output of a TakeIncident operation.
+type TakeIncidentOutput struct {
+	_        struct{}
+	Incident *Incident
+}
+
+// TakeIncident assigns the incident with specified id to the authenticated user and accepts it.
+// The API has no combined endpoint, so the incident is assigned and accepted in two calls
+func (c *Client) TakeIncident(input *TakeIncidentInput) (*TakeIncidentOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if input.IncidentID == nil {
+		return nil, errors.New("Incident id is required")
+	}
+
+	userID, err := c.getCurrentUserID()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.AssignIncident(&AssignIncidentInput{IncidentID: input.IncidentID, UserID: Int64(userID)})
+	if err != nil {
+		return nil, err
+	}
+
+	acceptOutput, err := c.AcceptIncident(&AcceptIncidentInput{IncidentID: input.IncidentID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TakeIncidentOutput{Incident: acceptOutput.Incident}, nil
+}
+
+// getIncidentActionResult parses the incident returned by an incident action. The API may answer an action
+// with an empty or non JSON body, in that case a minimal incident with the id and the new status is returned
+func getIncidentActionResult(operation string, resp *resty.Response, incidentID int64, status string) (*Incident, error) {
+	body := resp.Body()
+	if len(bytes.TrimSpace(body)) == 0 || !json.Valid(body) {
+		return &Incident{ID: incidentID, Status: status}, nil
+	}
+
+	incident := &Incident{}
+	err := decodeResponse(operation, resp, incident)
+	if err != nil {
+		return nil, err
+	}
+
+	return incident, nil
+}
+
+// UpdateIncidentInput represents the input of a UpdateIncident operation.
+type UpdateIncidentInput struct {
+	_          struct{}
+	IncidentID *int64
+	Incident   *Incident
+}
+
+// UpdateIncidentOutput represents the output of a UpdateIncident operation.
+type UpdateIncidentOutput struct {
+	_        struct{}
+	Incident *Incident
+}
+
+// UpdateIncident updates an existing incident. https://api.ilert.com/api-docs/#tag/Incidents/paths/~1incidents~1{id}/put
+func (c *Client) UpdateIncident(input *UpdateIncidentInput) (*UpdateIncidentOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if input.Incident == nil {
+		return nil, errors.New("Incident input is required")
+	}
+	if input.IncidentID == nil {
+		return nil, errors.New("Incident id is required")
+	}
+
+	resp, err := c.newRequest().SetBody(input.Incident).Put(fmt.Sprintf("%s/%d", apiRoutes.incidents, *input.IncidentID))
+	if err != nil {
+		return nil, err
+	}
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
+		return nil, apiErr
+	}
+
+	incident := &Incident{}
+	err = decodeResponse("UpdateIncident", resp, incident)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpdateIncidentOutput{Incident: incident}, nil
+}
+
+// UpdateIncidentPriorityInput represents the input of a UpdateIncidentPriority operation.
+type UpdateIncidentPriorityInput struct {
+	_          struct{}
+	IncidentID *int64
+	Priority   *string
+
+	// (optional) reason of the priority change, recorded as incident comment for the audit trail
+	Reason *string
+}
+
+// UpdateIncidentPriorityOutput represents the output of a UpdateIncidentPriority operation.
+type UpdateIncidentPriorityOutput struct {
+	_        struct{}
+	Incident *Incident
+}
+
+// UpdateIncidentPriority changes the priority of the incident with specified id
+func (c *Client) UpdateIncidentPriority(input *UpdateIncidentPriorityInput) (*UpdateIncidentPriorityOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if input.IncidentID == nil {
+		return nil, errors.New("Incident id is required")
+	}
+	if input.Priority == nil {
+		return nil, errors.New("priority is required")
+	}
+	if !isIncidentPriority(*input.Priority) {
+		return nil, fmt.Errorf("invalid incident priority %q", *input.Priority)
+	}
+
+	getOutput, err := c.GetIncident(&GetIncidentInput{IncidentID: input.IncidentID})
+	if err != nil {
+		return nil, err
+	}
+	incident := getOutput.Incident
+	previousPriority := incident.Priority
+	incident.Priority = *input.Priority
+
+	updateOutput, err := c.UpdateIncident(&UpdateIncidentInput{IncidentID: input.IncidentID, Incident: incident})
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Reason != nil && *input.Reason != "" && previousPriority != *input.Priority {
+		_, err = c.CreateIncidentComment(&CreateIncidentCommentInput{
+			IncidentID: input.IncidentID,
+			Comment: &IncidentComment{
+				Content: fmt.Sprintf("Priority changed from %s to %s: %s", previousPriority, *input.Priority, *input.Reason),
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("priority changed but the reason could not be recorded: %w", err)
+		}
+	}
+
+	return &UpdateIncidentPriorityOutput{Incident: updateOutput.Incident}, nil
+}
+
+// CreateIncidentCommentInput represents the input of a CreateIncidentComment operation.
+type CreateIncidentCommentInput struct {
+	_          struct{}
+	IncidentID *int64
+	Comment    *IncidentComment
+}
+
+// CreateIncidentCommentOutput represents the output of a CreateIncidentComment operation.
+type CreateIncidentCommentOutput struct {
+	_       struct{}
+	Comment *IncidentComment
+}
+
+// CreateIncidentComment adds a comment to the incident with specified id. https://api.ilert.com/api-docs/#tag/Incidents/paths/~1incidents~1{id}~1comments/post
+func (c *Client) CreateIncidentComment(input *CreateIncidentCommentInput) (*CreateIncidentCommentOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if input.IncidentID == nil {
+		return nil, errors.New("Incident id is required")
+	}
+	if input.Comment == nil {
+		return nil, errors.New("comment input is required")
+	}
+	if input.Comment.Content == "" {
+		return nil, errors.New("comment content is required")
+	}
+
+	resp, err := c.newRequest().SetBody(input.Comment).Post(fmt.Sprintf("%s/%d/comments", apiRoutes.incidents, *input.IncidentID))
+	if err != nil {
+		return nil, err
+	}
+	if apiErr := c.getGenericAPIError(resp, 200, 201); apiErr != nil {
+		return nil, apiErr
+	}
+
+	comment := &IncidentComment{}
+	err = decodeResponse("CreateIncidentComment", resp, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateIncidentCommentOutput{Comment: comment}, nil
+}
+
+// AppendIncidentDetails appends text to the details of the incident with specified id, separated from the
+// existing details by a timestamped line. The incident is fetched and updated in two calls; the API exposes
+// no version for optimistic concurrency, so appends racing within that window may still overwrite each other
+func (c *Client) AppendIncidentDetails(incidentID int64, text string) (*Incident, error) {
+	if text == "" {
+		return nil, errors.New("text is required")
+	}
+
+	getOutput, err := c.GetIncident(&GetIncidentInput{IncidentID: Int64(incidentID)})
+	if err != nil {
+		return nil, err
+	}
+	incident := getOutput.Incident
+
+	entry := fmt.Sprintf("--- %s ---\n%s", time.Now().UTC().Format(time.RFC3339), text)
+	if incident.Details == "" {
+		incident.Details = entry
+	} else {
+		incident.Details = incident.Details + "\n\n" + entry
+	}
+
+	updateOutput, err := c.UpdateIncident(&UpdateIncidentInput{IncidentID: Int64(incidentID), Incident: incident})
+	if err != nil {
+		return nil, err
+	}
+
+	return updateOutput.Incident, nil
+}
+
+// EscalateStaleIncidents changes the priority of all open incidents that still have fromPriority
+// and were reported more than olderThan ago to toPriority. Returns the number of changed incidents
+func (c *Client) EscalateStaleIncidents(olderThan time.Duration, fromPriority, toPriority string) (int, error) {
+	if !isIncidentPriority(fromPriority) {
+		return 0, fmt.Errorf("invalid incident priority %q", fromPriority)
+	}
+	if !isIncidentPriority(toPriority) {
+		return 0, fmt.Errorf("invalid incident priority %q", toPriority)
+	}
+	if fromPriority == toPriority {
+		return 0, nil
+	}
+
+	threshold := time.Now().Add(-olderThan)
+	stale := make([]int64, 0)
+	err := c.forEachIncidentsPage(&GetIncidentsInput{
+		States: []*string{
+			String(IncidentStatuses.New),
+			String(IncidentStatuses.Pending),
+			String(IncidentStatuses.Accepted),
+		},
+	}, func(incidents []*Incident) error {
+		for _, incident := range incidents {
+			if incident.Priority != fromPriority {
+				continue
+			}
+			reportTime, err := c.parseTime(incident.ReportTime)
+			if err != nil {
+				return fmt.Errorf("incident %d: invalid report time: %w", incident.ID, err)
+			}
+			if reportTime.Before(threshold) {
+				stale = append(stale, incident.ID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	for _, incidentID := range stale {
+		_, err := c.UpdateIncidentPriority(&UpdateIncidentPriorityInput{
+			IncidentID: Int64(incidentID),
+			Priority:   String(toPriority),
+		})
+		if err != nil {
+			return changed, err
+		}
+		changed++
+	}
+
+	return changed, nil
+}
+
+func isIncidentPriority(priority string) bool {
+	return priority == IncidentPriorities.High || priority == IncidentPriorities.Low
+}
+
 // GetIncidentLogEntriesInput represents the input of a GetIncidentLogEntries operation.
 type GetIncidentLogEntriesInput struct {
 	_          struct{}
@@ -581,16 +1630,16 @@ func (c *Client) GetIncidentLogEntries(input *GetIncidentLogEntriesInput) (*GetI
 		}
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d/log-entries", apiRoutes.incidents, *input.IncidentID))
+	resp, err := c.newRequest().Get(fmt.Sprintf("%s/%d/log-entries", apiRoutes.incidents, *input.IncidentID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	incidentLogEntries := make([]*IncidentLogEntry, 0)
-	err = json.Unmarshal(resp.Body(), &incidentLogEntries)
+	err = decodeResponse("GetIncidentLogEntries", resp, &incidentLogEntries)
 	if err != nil {
 		return nil, err
 	}
@@ -621,16 +1670,16 @@ func (c *Client) GetIncidentActions(input *GetIncidentActionsInput) (*GetInciden
 		return nil, errors.New("Incident id is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d/actions", apiRoutes.incidents, *input.IncidentID))
+	resp, err := c.newRequest().Get(fmt.Sprintf("%s/%d/actions", apiRoutes.incidents, *input.IncidentID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	incidentActions := make([]*IncidentAction, 0)
-	err = json.Unmarshal(resp.Body(), &incidentActions)
+	err = decodeResponse("GetIncidentActions", resp, &incidentActions)
 	if err != nil {
 		return nil, err
 	}
@@ -649,6 +1698,10 @@ type InvokeIncidentActionInput struct {
 type InvokeIncidentActionOutput struct {
 	_      struct{}
 	Action *IncidentAction
+
+	// true if the API accepted the action for asynchronous processing (202 Accepted), Action may be nil.
+	// Use WaitForIncidentActionResult to wait for the result
+	Pending bool
 }
 
 // InvokeIncidentAction creates a new alert source. https://api.ilert.com/api-docs/#tag/Incident-Actions/paths/~1incidents~1{id}~1actions/post
@@ -663,19 +1716,57 @@ func (c *Client) InvokeIncidentAction(input *InvokeIncidentActionInput) (*Invoke
 		return nil, errors.New("action input is required")
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.Action).Post(fmt.Sprintf("%s/%d/actions", apiRoutes.incidents, *input.IncidentID))
+	resp, err := c.newRequest().SetBody(input.Action).Post(fmt.Sprintf("%s/%d/actions", apiRoutes.incidents, *input.IncidentID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 201); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 201, 202); apiErr != nil {
 		return nil, apiErr
 	}
 
+	pending := resp.StatusCode() == 202
+	if pending && len(bytes.TrimSpace(resp.Body())) == 0 {
+		return &InvokeIncidentActionOutput{Pending: true}, nil
+	}
+
 	incidentAction := &IncidentAction{}
-	err = json.Unmarshal(resp.Body(), incidentAction)
+	err = decodeResponse("InvokeIncidentAction", resp, incidentAction)
 	if err != nil {
 		return nil, err
 	}
 
-	return &InvokeIncidentActionOutput{Action: incidentAction}, nil
+	return &InvokeIncidentActionOutput{Action: incidentAction, Pending: pending}, nil
+}
+
+// WaitForIncidentActionResult polls the actions of the incident with specified id until the history of the action
+// with the webhook id has more than previousResults entries and returns the newest result. Pass the length of the
+// action history before invoking the action as previousResults. Stops when the context is cancelled
+func (c *Client) WaitForIncidentActionResult(ctx context.Context, incidentID int64, webhookID string, previousResults int, pollInterval time.Duration) (*IncidentActionResult, error) {
+	if webhookID == "" {
+		return nil, errors.New("webhook id is required")
+	}
+	if pollInterval <= 0 {
+		return nil, errors.New("poll interval must be positive")
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		output, err := c.GetIncidentActions(&GetIncidentActionsInput{IncidentID: Int64(incidentID)})
+		if err != nil {
+			return nil, err
+		}
+		for _, action := range output.Actions {
+			if action.WebhookID == webhookID && len(action.History) > previousResults {
+				result := action.History[len(action.History)-1]
+				return &result, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
 }
@@ -0,0 +1,391 @@
+package ilert
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestGetIncidentsInputToQueryUntil(t *testing.T) {
+	input := &GetIncidentsInput{
+		From:  String("2021-01-01T00:00:00Z"),
+		Until: String("2021-02-01T00:00:00Z"),
+	}
+
+	q := input.toQuery()
+	if got := q.Get("from"); got != "2021-01-01T00:00:00Z" {
+		t.Errorf("from = %q, want %q", got, "2021-01-01T00:00:00Z")
+	}
+	if got := q.Get("until"); got != "2021-02-01T00:00:00Z" {
+		t.Errorf("until = %q, want %q", got, "2021-02-01T00:00:00Z")
+	}
+}
+
+func TestGetIncidentsCountInputToQueryUntil(t *testing.T) {
+	input := &GetIncidentsCountInput{
+		From:  String("2021-01-01T00:00:00Z"),
+		Until: String("2021-02-01T00:00:00Z"),
+	}
+
+	q := input.toQuery()
+	if got := q.Get("from"); got != "2021-01-01T00:00:00Z" {
+		t.Errorf("from = %q, want %q", got, "2021-01-01T00:00:00Z")
+	}
+	if got := q.Get("until"); got != "2021-02-01T00:00:00Z" {
+		t.Errorf("until = %q, want %q", got, "2021-02-01T00:00:00Z")
+	}
+}
+
+func TestGetIncidentsPaged(t *testing.T) {
+	pages := [][]*Incident{
+		{{ID: 1}, {ID: 2}},
+		{{ID: 3}, {ID: 4}},
+		{{ID: 5}},
+	}
+	queries := make([]url.Values, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		queries = append(queries, q)
+		startIndex, _ := strconv.Atoi(q.Get("start-index"))
+		page := make([]*Incident, 0)
+		if i := startIndex / 2; i < len(pages) {
+			page = pages[i]
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIEndpoint(server.URL))
+	incidents, err := client.GetIncidentsPaged(&GetIncidentsInput{
+		MaxResults:   Int(2),
+		States:       []*string{String(IncidentStatuses.Accepted)},
+		AlertSources: []*int64{Int64(42)},
+	})
+	if err != nil {
+		t.Fatalf("GetIncidentsPaged: %v", err)
+	}
+
+	ids := make([]int64, 0, len(incidents))
+	for _, incident := range incidents {
+		ids = append(ids, incident.ID)
+	}
+	if want := []int64{1, 2, 3, 4, 5}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("incident ids = %v, want %v", ids, want)
+	}
+
+	if len(queries) != 3 {
+		t.Fatalf("got %d requests, want 3", len(queries))
+	}
+	for i, q := range queries {
+		if got, want := q.Get("start-index"), strconv.Itoa(i*2); got != want {
+			t.Errorf("page %d: start-index = %q, want %q", i, got, want)
+		}
+		if got := q.Get("max-results"); got != "2" {
+			t.Errorf("page %d: max-results = %q, want %q", i, got, "2")
+		}
+		if got := q.Get("state"); got != IncidentStatuses.Accepted {
+			t.Errorf("page %d: state = %q, want %q", i, got, IncidentStatuses.Accepted)
+		}
+		if got := q.Get("alert-source"); got != "42" {
+			t.Errorf("page %d: alert-source = %q, want %q", i, got, "42")
+		}
+	}
+}
+
+func TestAddAssignedToQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		userIDs   []*int64
+		usernames []*string
+		want      []string
+	}{
+		{"ids", []*int64{Int64(1), Int64(2)}, nil, []string{"1", "2"}},
+		{"usernames", nil, []*string{String("alice"), String("bob")}, []string{"alice", "bob"}},
+		{"mixed ids and usernames of the same user", []*int64{Int64(1)}, []*string{String("alice")}, []string{"1", "alice"}},
+		{"duplicate values", []*int64{Int64(1), Int64(1)}, []*string{String("alice"), String("alice"), String("1")}, []string{"1", "alice"}},
+		{"nil and empty values", []*int64{nil}, []*string{nil, String("")}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := url.Values{}
+			addAssignedToQuery(q, tt.userIDs, tt.usernames)
+			if got := q["assigned-to"]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("assigned-to = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncidentCustomDetailsUseNumber(t *testing.T) {
+	incident := &Incident{}
+	if err := json.Unmarshal([]byte(`{"id":5,"customDetails":{"pod":"web-1","restarts":9007199254740993}}`), incident); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	restarts, err := incident.CustomDetailInt64("restarts")
+	if err != nil {
+		t.Fatalf("CustomDetailInt64: %v", err)
+	}
+	if restarts != 9007199254740993 {
+		t.Errorf("restarts = %d, want 9007199254740993", restarts)
+	}
+	if pod, _ := incident.CustomDetailString("pod"); pod != "web-1" {
+		t.Errorf("pod = %q, want %q", pod, "web-1")
+	}
+}
+
+func TestIncidentEmbeddedDecoding(t *testing.T) {
+	type extendedIncident struct {
+		Incident
+		Extra string `json:"extra"`
+	}
+
+	incident := &extendedIncident{}
+	if err := json.Unmarshal([]byte(`{"id":5,"extra":"x","customDetails":{"restarts":3}}`), incident); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if incident.ID != 5 {
+		t.Errorf("ID = %d, want 5", incident.ID)
+	}
+	if incident.Extra != "x" {
+		t.Errorf("Extra = %q, want %q", incident.Extra, "x")
+	}
+	if restarts, err := incident.CustomDetailInt64("restarts"); err != nil || restarts != 3 {
+		t.Errorf("restarts = %d, %v, want 3", restarts, err)
+	}
+}
+
+type extendedTestIncident struct {
+	Incident
+	Runbook string `json:"runbook"`
+}
+
+func TestGetIncidentInto(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/incidents" {
+			w.Write([]byte(`[{"id":1,"summary":"disk full","runbook":"https://runbooks.example.com/disk"},{"id":2,"summary":"cpu","runbook":""}]`))
+			return
+		}
+		w.Write([]byte(`{"id":1,"summary":"disk full","status":"PENDING","runbook":"https://runbooks.example.com/disk"}`))
+	}))
+	defer server.Close()
+	client := NewClient(WithAPIEndpoint(server.URL))
+
+	incident := &extendedTestIncident{}
+	if err := client.GetIncidentInto(&GetIncidentInput{IncidentID: Int64(1)}, incident); err != nil {
+		t.Fatalf("GetIncidentInto: %v", err)
+	}
+	if incident.ID != 1 || incident.Summary != "disk full" || incident.Status != IncidentStatuses.Pending {
+		t.Errorf("base fields = %d, %q, %q, want 1, %q, %q", incident.ID, incident.Summary, incident.Status, "disk full", IncidentStatuses.Pending)
+	}
+	if incident.Runbook != "https://runbooks.example.com/disk" {
+		t.Errorf("Runbook = %q, want %q", incident.Runbook, "https://runbooks.example.com/disk")
+	}
+
+	incidents := make([]*extendedTestIncident, 0)
+	if err := client.GetIncidentsInto(&GetIncidentsInput{}, &incidents); err != nil {
+		t.Fatalf("GetIncidentsInto: %v", err)
+	}
+	if len(incidents) != 2 || incidents[0].ID != 1 || incidents[0].Runbook != "https://runbooks.example.com/disk" || incidents[1].ID != 2 {
+		t.Errorf("incidents = %+v", incidents)
+	}
+}
+
+func TestGetIncidentIntoInvalidOutput(t *testing.T) {
+	client := NewClient(WithAPIEndpoint("http://127.0.0.1:0"))
+	var nilIncident *extendedTestIncident
+	tests := []struct {
+		name string
+		out  interface{}
+	}{
+		{"nil", nil},
+		{"nil pointer", nilIncident},
+		{"non-pointer", extendedTestIncident{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := client.GetIncidentInto(&GetIncidentInput{IncidentID: Int64(1)}, tt.out); err == nil {
+				t.Error("GetIncidentInto: expected an error")
+			}
+			if err := client.GetIncidentsInto(&GetIncidentsInput{}, tt.out); err == nil {
+				t.Error("GetIncidentsInto: expected an error")
+			}
+		})
+	}
+}
+
+func TestIncidentActionsEmptyResponse(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"empty 200", http.StatusOK, ""},
+		{"empty 204", http.StatusNoContent, ""},
+		{"non JSON 200", http.StatusOK, "OK"},
+	}
+	actions := []struct {
+		name   string
+		status string
+		fn     func(client *Client) (*Incident, error)
+	}{
+		{"accept", IncidentStatuses.Accepted, func(client *Client) (*Incident, error) {
+			output, err := client.AcceptIncident(&AcceptIncidentInput{IncidentID: Int64(7)})
+			if err != nil {
+				return nil, err
+			}
+			return output.Incident, nil
+		}},
+		{"resolve", IncidentStatuses.Resolved, func(client *Client) (*Incident, error) {
+			output, err := client.ResolveIncident(&ResolveIncidentInput{IncidentID: Int64(7)})
+			if err != nil {
+				return nil, err
+			}
+			return output.Incident, nil
+		}},
+	}
+	for _, tt := range tests {
+		for _, action := range actions {
+			t.Run(action.name+" "+tt.name, func(t *testing.T) {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(tt.status)
+					w.Write([]byte(tt.body))
+				}))
+				defer server.Close()
+
+				incident, err := action.fn(NewClient(WithAPIEndpoint(server.URL)))
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if incident.ID != 7 || incident.Status != action.status {
+					t.Errorf("incident = %d, %q, want 7, %q", incident.ID, incident.Status, action.status)
+				}
+			})
+		}
+	}
+}
+
+func TestGetIncidentsFilterQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		input *GetIncidentsInput
+		want  string
+	}{
+		{
+			name:  "multiple states",
+			input: &GetIncidentsInput{States: []*string{String(IncidentStatuses.New), String(IncidentStatuses.Pending)}},
+			want:  "state=NEW&state=PENDING",
+		},
+		{
+			name: "states and alert sources",
+			input: &GetIncidentsInput{
+				States:       []*string{String(IncidentStatuses.New), String(IncidentStatuses.Pending)},
+				AlertSources: []*int64{Int64(1), Int64(2)},
+			},
+			want: "alert-source=1&alert-source=2&state=NEW&state=PENDING",
+		},
+		{
+			name: "states, alert sources and assignees",
+			input: &GetIncidentsInput{
+				States:              []*string{String(IncidentStatuses.Accepted)},
+				AlertSources:        []*int64{Int64(1)},
+				AssignedToUserIDs:   []*int64{Int64(5)},
+				AssignedToUserNames: []*string{String("alice")},
+			},
+			want: "alert-source=1&assigned-to=5&assigned-to=alice&state=ACCEPTED",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.input.toQuery().Encode(); got != tt.want {
+				t.Errorf("query = %s, want %s", got, tt.want)
+			}
+
+			countInput := &GetIncidentsCountInput{
+				States:              tt.input.States,
+				AlertSources:        tt.input.AlertSources,
+				AssignedToUserIDs:   tt.input.AssignedToUserIDs,
+				AssignedToUserNames: tt.input.AssignedToUserNames,
+			}
+			if got := countInput.toQuery().Encode(); got != tt.want {
+				t.Errorf("count query = %s, want %s", got, tt.want)
+			}
+
+			var rawQuery string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				rawQuery = r.URL.RawQuery
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte("[]"))
+			}))
+			defer server.Close()
+			if _, err := NewClient(WithAPIEndpoint(server.URL)).GetIncidents(tt.input); err != nil {
+				t.Fatalf("GetIncidents: %v", err)
+			}
+			if rawQuery != tt.want {
+				t.Errorf("request query = %s, want %s", rawQuery, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncidentActorTypes(t *testing.T) {
+	if want := []string{"USER", "SOURCE"}; !reflect.DeepEqual(IncidentActorTypesAll, want) {
+		t.Errorf("IncidentActorTypesAll = %v, want %v", IncidentActorTypesAll, want)
+	}
+
+	tests := []struct {
+		name                   string
+		json                   string
+		wantAcknowledgedByUser bool
+		wantResolvedByUser     bool
+		wantResolvedBySystem   bool
+	}{
+		{
+			name: "open",
+			json: `{"id":1,"status":"PENDING"}`,
+		},
+		{
+			name:                   "acknowledged by user",
+			json:                   `{"id":2,"status":"ACCEPTED","acknowledgedBy":{"id":5},"acknowledgedByType":"USER"}`,
+			wantAcknowledgedByUser: true,
+		},
+		{
+			name:                   "resolved by user",
+			json:                   `{"id":3,"status":"RESOLVED","acknowledgedByType":"USER","resolvedBy":{"id":5},"resolvedByType":"USER"}`,
+			wantAcknowledgedByUser: true,
+			wantResolvedByUser:     true,
+		},
+		{
+			name:                 "resolved by alert source",
+			json:                 `{"id":4,"status":"RESOLVED","resolvedByType":"SOURCE"}`,
+			wantResolvedBySystem: true,
+		},
+		{
+			name:                 "resolved by unknown actor type",
+			json:                 `{"id":5,"status":"RESOLVED","resolvedByType":"AUTO_RESOLVE"}`,
+			wantResolvedBySystem: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			incident := &Incident{}
+			if err := json.Unmarshal([]byte(tt.json), incident); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if got := incident.WasAcknowledgedByUser(); got != tt.wantAcknowledgedByUser {
+				t.Errorf("WasAcknowledgedByUser = %t, want %t", got, tt.wantAcknowledgedByUser)
+			}
+			if got := incident.WasResolvedByUser(); got != tt.wantResolvedByUser {
+				t.Errorf("WasResolvedByUser = %t, want %t", got, tt.wantResolvedByUser)
+			}
+			if got := incident.WasResolvedBySystem(); got != tt.wantResolvedBySystem {
+				t.Errorf("WasResolvedBySystem = %t, want %t", got, tt.wantResolvedBySystem)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package ilert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DownloadIncidentImage downloads the image of an incident and returns its data and content type.
+// Images hosted by ilert (the API endpoint host or *.ilert.com) are fetched with the authentication of the client,
+// external images with the transport of the client (e.g. its proxy) but without sending the credentials
+func (c *Client) DownloadIncidentImage(img IncidentImage) ([]byte, string, error) {
+	if img.Src == "" {
+		return nil, "", errors.New("image src is required")
+	}
+	src, err := url.Parse(img.Src)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid image src %q: %w", img.Src, err)
+	}
+	if src.Scheme != "http" && src.Scheme != "https" {
+		return nil, "", fmt.Errorf("invalid image src %q: absolute http(s) url required", img.Src)
+	}
+
+	if c.isILERTHost(src.Hostname()) {
+		resp, err := c.newRequest().SetHeader("Accept", "*/*").Get(src.String())
+		if err != nil {
+			return nil, "", err
+		}
+		if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
+			return nil, "", apiErr
+		}
+		return resp.Body(), resp.Header().Get("Content-Type"), nil
+	}
+
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src.String(), nil)
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := c.httpClient.GetClient().Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("downloading image %s: unexpected status %d", img.Src, resp.StatusCode)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, resp.Header.Get("Content-Type"), nil
+}
+
+// isILERTHost reports whether requests to host may carry the credentials of the client
+func (c *Client) isILERTHost(host string) bool {
+	host = strings.ToLower(host)
+	if endpoint, err := url.Parse(c.apiEndpoint); err == nil && strings.EqualFold(endpoint.Hostname(), host) {
+		return true
+	}
+	return host == "ilert.com" || strings.HasSuffix(host, ".ilert.com")
+}
@@ -0,0 +1,78 @@
+package ilert
+
+import (
+	"fmt"
+	"time"
+)
+
+// SLAPolicy defines the time an incident may take until it is acknowledged and resolved, by incident priority.
+// Priorities without entry or with a non-positive duration have no target
+type SLAPolicy struct {
+	// time from the report until the incident is acknowledged, keyed by IncidentPriorities
+	AcknowledgeWithin map[string]time.Duration
+
+	// time from the report until the incident is resolved, keyed by IncidentPriorities
+	ResolveWithin map[string]time.Duration
+}
+
+// SLAResult is the SLA status of an incident, see Incident.SLAStatus
+type SLAResult struct {
+	// deadline for the acknowledgement, zero if the policy has no acknowledge target for the priority
+	AcknowledgeDeadline time.Time
+
+	// deadline for the resolution, zero if the policy has no resolve target for the priority
+	ResolveDeadline time.Time
+
+	// the incident was not acknowledged before the acknowledge deadline
+	AcknowledgeBreached bool
+
+	// the incident was resolved after the resolve deadline, or is still open and the deadline has passed
+	ResolveBreached bool
+}
+
+// SLAStatus computes the acknowledge and resolve deadlines of the incident from its report time and the targets of
+// its priority in the policy, and whether they are breached at the current time.
+// The incident does not carry an acknowledgement time, so an acknowledge breach is only reported for incidents that
+// are still new or pending, or that were resolved after the deadline without being acknowledged by a user.
+// For the exact acknowledgement time of an incident use TimeToAcknowledge with its log entries.
+// Additional layouts are used like in TimeToAcknowledge
+func (i *Incident) SLAStatus(policy SLAPolicy, additionalLayouts ...string) (SLAResult, error) {
+	result := SLAResult{}
+	now := time.Now()
+
+	reportTime, err := parseILERTTime(i.ReportTime, additionalLayouts...)
+	if err != nil {
+		return result, fmt.Errorf("incident %d: invalid report time: %w", i.ID, err)
+	}
+
+	var resolvedOn time.Time
+	resolved := i.Status == IncidentStatuses.Resolved && i.ResolvedOn != ""
+	if resolved {
+		resolvedOn, err = parseILERTTime(i.ResolvedOn, additionalLayouts...)
+		if err != nil {
+			return result, fmt.Errorf("incident %d: invalid resolved on time: %w", i.ID, err)
+		}
+	}
+
+	if within := policy.AcknowledgeWithin[i.Priority]; within > 0 {
+		result.AcknowledgeDeadline = reportTime.Add(within)
+		switch {
+		case resolved:
+			// resolving acknowledges the incident at the latest
+			result.AcknowledgeBreached = resolvedOn.After(result.AcknowledgeDeadline) && !i.WasAcknowledgedByUser()
+		case i.Status == IncidentStatuses.New || i.Status == IncidentStatuses.Pending:
+			result.AcknowledgeBreached = now.After(result.AcknowledgeDeadline)
+		}
+	}
+
+	if within := policy.ResolveWithin[i.Priority]; within > 0 {
+		result.ResolveDeadline = reportTime.Add(within)
+		if resolved {
+			result.ResolveBreached = resolvedOn.After(result.ResolveDeadline)
+		} else {
+			result.ResolveBreached = now.After(result.ResolveDeadline)
+		}
+	}
+
+	return result, nil
+}
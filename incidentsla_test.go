@@ -0,0 +1,100 @@
+package ilert
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIncidentSLAStatus(t *testing.T) {
+	now := time.Now().UTC()
+	ago := func(d time.Duration) string {
+		return now.Add(-d).Format(time.RFC3339)
+	}
+	policy := SLAPolicy{
+		AcknowledgeWithin: map[string]time.Duration{IncidentPriorities.High: time.Hour},
+		ResolveWithin:     map[string]time.Duration{IncidentPriorities.High: 4 * time.Hour},
+	}
+
+	tests := []struct {
+		name                    string
+		incident                *Incident
+		wantDeadlines           bool
+		wantAcknowledgeBreached bool
+		wantResolveBreached     bool
+	}{
+		{
+			name: "acknowledged in time",
+			incident: &Incident{
+				Priority:           IncidentPriorities.High,
+				Status:             IncidentStatuses.Accepted,
+				ReportTime:         ago(30 * time.Minute),
+				AcknowledgedByType: IncidentActorTypes.User,
+			},
+			wantDeadlines: true,
+		},
+		{
+			name: "resolved late and unacknowledged",
+			incident: &Incident{
+				Priority:       IncidentPriorities.High,
+				Status:         IncidentStatuses.Resolved,
+				ReportTime:     ago(10 * time.Hour),
+				ResolvedOn:     ago(time.Hour),
+				ResolvedByType: IncidentActorTypes.AlertSource,
+			},
+			wantDeadlines:           true,
+			wantAcknowledgeBreached: true,
+			wantResolveBreached:     true,
+		},
+		{
+			name: "open past the deadline",
+			incident: &Incident{
+				Priority:   IncidentPriorities.High,
+				Status:     IncidentStatuses.Pending,
+				ReportTime: ago(2 * time.Hour),
+			},
+			wantDeadlines:           true,
+			wantAcknowledgeBreached: true,
+		},
+		{
+			name: "priority without target",
+			incident: &Incident{
+				Priority:   IncidentPriorities.Low,
+				Status:     IncidentStatuses.Pending,
+				ReportTime: ago(10 * time.Hour),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.incident.SLAStatus(policy)
+			if err != nil {
+				t.Fatalf("SLAStatus: %v", err)
+			}
+			if got := !result.AcknowledgeDeadline.IsZero() && !result.ResolveDeadline.IsZero(); got != tt.wantDeadlines {
+				t.Errorf("deadlines set = %t, want %t (%+v)", got, tt.wantDeadlines, result)
+			}
+			if result.AcknowledgeBreached != tt.wantAcknowledgeBreached {
+				t.Errorf("AcknowledgeBreached = %t, want %t", result.AcknowledgeBreached, tt.wantAcknowledgeBreached)
+			}
+			if result.ResolveBreached != tt.wantResolveBreached {
+				t.Errorf("ResolveBreached = %t, want %t", result.ResolveBreached, tt.wantResolveBreached)
+			}
+		})
+	}
+}
+
+func TestIncidentSLAStatusAdditionalLayouts(t *testing.T) {
+	incident := &Incident{Priority: IncidentPriorities.High, Status: IncidentStatuses.Pending, ReportTime: "01.02.2021 10:00"}
+	policy := SLAPolicy{AcknowledgeWithin: map[string]time.Duration{IncidentPriorities.High: time.Hour}}
+
+	if _, err := incident.SLAStatus(policy); err == nil {
+		t.Fatal("expected an error without additional layouts")
+	}
+	result, err := incident.SLAStatus(policy, "02.01.2006 15:04")
+	if err != nil {
+		t.Fatalf("SLAStatus: %v", err)
+	}
+	if want := time.Date(2021, 2, 1, 11, 0, 0, 0, time.UTC); !result.AcknowledgeDeadline.Equal(want) {
+		t.Errorf("AcknowledgeDeadline = %s, want %s", result.AcknowledgeDeadline, want)
+	}
+}
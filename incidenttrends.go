@@ -0,0 +1,170 @@
+package ilert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// IncidentTrendBucketSizes defines the bucket sizes of incident trends
+var IncidentTrendBucketSizes = struct {
+	Day  string
+	Week string
+}{
+	Day:  "day",
+	Week: "week",
+}
+
+// IncidentTrendGroupings defines how the incidents of a trend bucket are counted
+var IncidentTrendGroupings = struct {
+	State    string
+	Priority string
+}{
+	State:    "state",
+	Priority: "priority",
+}
+
+// MaxIncidentTrendBuckets is the largest number of buckets of a GetIncidentTrends operation, e.g. a year of days
+const MaxIncidentTrendBuckets = 366
+
+// IncidentTrendsInput represents the input of a GetIncidentTrends operation.
+type IncidentTrendsInput struct {
+	_ struct{}
+
+	// start of the first bucket
+	From time.Time
+
+	// end of the last bucket, the last bucket is shortened to end at Until
+	Until time.Time
+
+	// one of IncidentTrendBucketSizes
+	BucketSize string
+
+	// (optional) one of IncidentTrendGroupings. Default: state
+	GroupBy string
+
+	// (optional) incident states to count. Default: all states
+	States []string
+
+	// (optional) alert source IDs of the counted incidents
+	AlertSources []*int64
+
+	// (optional) maximum number of requests in parallel. Default: 5
+	Concurrency *int
+}
+
+// IncidentTrendBucket is the number of incidents reported within a time bucket, per state or priority
+type IncidentTrendBucket struct {
+	Start  time.Time
+	End    time.Time
+	Counts map[string]int // incident state or priority, depending on GroupBy -> count
+}
+
+// IncidentTrendsOutput represents the output of a GetIncidentTrends operation.
+type IncidentTrendsOutput struct {
+	_       struct{}
+	Buckets []IncidentTrendBucket
+}
+
+// GetIncidentTrends counts the incidents in time buckets between From and Until, e.g. for trend charts of the
+// HIGH incidents per week. The API has no statistics endpoint: grouped by state one GetIncidentsCount call is made
+// per bucket and state, grouped by priority the incidents of each bucket are paged through and counted by their
+// priority, as the count endpoint can not filter by priority. At most MaxIncidentTrendBuckets buckets are allowed.
+// The first failed request stops the operation and its error is returned
+func (c *Client) GetIncidentTrends(input *IncidentTrendsInput) (*IncidentTrendsOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if !input.Until.After(input.From) {
+		return nil, errors.New("until must be after from")
+	}
+
+	var step func(t time.Time) time.Time
+	switch input.BucketSize {
+	case IncidentTrendBucketSizes.Day:
+		step = func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+	case IncidentTrendBucketSizes.Week:
+		step = func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }
+	default:
+		return nil, fmt.Errorf("invalid bucket size %q", input.BucketSize)
+	}
+
+	groupBy := input.GroupBy
+	if groupBy == "" {
+		groupBy = IncidentTrendGroupings.State
+	}
+	if groupBy != IncidentTrendGroupings.State && groupBy != IncidentTrendGroupings.Priority {
+		return nil, fmt.Errorf("invalid trend grouping %q", input.GroupBy)
+	}
+
+	states := input.States
+	if len(states) == 0 {
+		states = []string{IncidentStatuses.New, IncidentStatuses.Pending, IncidentStatuses.Accepted, IncidentStatuses.Resolved}
+	}
+
+	buckets := make([]IncidentTrendBucket, 0)
+	for start := input.From; start.Before(input.Until); start = step(start) {
+		if len(buckets) == MaxIncidentTrendBuckets {
+			return nil, fmt.Errorf("too many trend buckets, at most %d are allowed", MaxIncidentTrendBuckets)
+		}
+		end := step(start)
+		if end.After(input.Until) {
+			end = input.Until
+		}
+		buckets = append(buckets, IncidentTrendBucket{Start: start, End: end, Counts: make(map[string]int)})
+	}
+
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	mu := sync.Mutex{}
+
+	var err error
+	if groupBy == IncidentTrendGroupings.Priority {
+		statePtrs := make([]*string, 0, len(states))
+		for _, state := range states {
+			statePtrs = append(statePtrs, String(state))
+		}
+		err = batchExecute(ctx, len(buckets), bulkConcurrency(input.Concurrency), true, func(ctx context.Context, i int) error {
+			bucket := &buckets[i]
+			return c.withContext(ctx).forEachIncidentsPage(&GetIncidentsInput{
+				MaxResults:   Int(MaxPageSize),
+				States:       statePtrs,
+				AlertSources: input.AlertSources,
+				From:         String(bucket.Start.UTC().Format(time.RFC3339)),
+				Until:        String(bucket.End.UTC().Format(time.RFC3339)),
+			}, func(incidents []*Incident) error {
+				for _, incident := range incidents {
+					bucket.Counts[incident.Priority]++
+				}
+				return nil
+			})
+		})
+	} else {
+		err = batchExecute(ctx, len(buckets)*len(states), bulkConcurrency(input.Concurrency), true, func(ctx context.Context, i int) error {
+			bucket := &buckets[i/len(states)]
+			state := states[i%len(states)]
+			output, err := c.withContext(ctx).GetIncidentsCount(&GetIncidentsCountInput{
+				States:       []*string{String(state)},
+				AlertSources: input.AlertSources,
+				From:         String(bucket.Start.UTC().Format(time.RFC3339)),
+				Until:        String(bucket.End.UTC().Format(time.RFC3339)),
+			})
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			bucket.Counts[state] = output.Count
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err != nil {
+		return nil, firstError(err)
+	}
+
+	return &IncidentTrendsOutput{Buckets: buckets}, nil
+}
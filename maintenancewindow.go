@@ -0,0 +1,225 @@
+package ilert
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaintenanceWindow definition https://api.ilert.com/api-docs/#tag/Maintenance-Windows
+type MaintenanceWindow struct {
+	ID           int64              `json:"id,omitempty"`
+	Summary      string             `json:"summary"`
+	Description  string             `json:"description,omitempty"`
+	Start        string             `json:"start"` // Date time string in ISO format
+	End          string             `json:"end"`   // Date time string in ISO format
+	Timezone     string             `json:"timezone,omitempty"`
+	CreatedBy    string             `json:"createdBy,omitempty"` // read only
+	AlertSources []AlertSourceShort `json:"alertSources"`
+}
+
+// validateMaintenanceWindow checks the time window and the affected alert sources of the maintenance window,
+// the times are parsed with the layouts of the client, see WithTimeLayouts
+func (c *Client) validateMaintenanceWindow(maintenanceWindow *MaintenanceWindow) error {
+	if maintenanceWindow.Start == "" || maintenanceWindow.End == "" {
+		return errors.New("maintenance window start and end are required")
+	}
+	start, err := c.parseTime(maintenanceWindow.Start)
+	if err != nil {
+		return fmt.Errorf("invalid maintenance window start: %w", err)
+	}
+	end, err := c.parseTime(maintenanceWindow.End)
+	if err != nil {
+		return fmt.Errorf("invalid maintenance window end: %w", err)
+	}
+	if !end.After(start) {
+		return errors.New("maintenance window end must be after start")
+	}
+	if len(maintenanceWindow.AlertSources) == 0 {
+		return errors.New("maintenance window requires at least one alert source")
+	}
+	return nil
+}
+
+// CreateMaintenanceWindowInput represents the input of a CreateMaintenanceWindow operation.
+type CreateMaintenanceWindowInput struct {
+	_                 struct{}
+	MaintenanceWindow *MaintenanceWindow
+}
+
+// CreateMaintenanceWindowOutput represents the output of a CreateMaintenanceWindow operation.
+type CreateMaintenanceWindowOutput struct {
+	_                 struct{}
+	MaintenanceWindow *MaintenanceWindow
+}
+
+// CreateMaintenanceWindow creates a new maintenance window. https://api.ilert.com/api-docs/#tag/Maintenance-Windows/paths/~1maintenance-windows/post
+func (c *Client) CreateMaintenanceWindow(input *CreateMaintenanceWindowInput) (*CreateMaintenanceWindowOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if input.MaintenanceWindow == nil {
+		return nil, errors.New("maintenance window input is required")
+	}
+	if err := c.validateMaintenanceWindow(input.MaintenanceWindow); err != nil {
+		return nil, err
+	}
+	resp, err := c.newRequest().SetBody(input.MaintenanceWindow).Post(apiRoutes.maintenanceWindows)
+	if err != nil {
+		return nil, err
+	}
+	if apiErr := c.getGenericAPIError(resp, 201); apiErr != nil {
+		return nil, apiErr
+	}
+
+	maintenanceWindow := &MaintenanceWindow{}
+	err = decodeResponse("CreateMaintenanceWindow", resp, maintenanceWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateMaintenanceWindowOutput{MaintenanceWindow: maintenanceWindow}, nil
+}
+
+// GetMaintenanceWindowInput represents the input of a GetMaintenanceWindow operation.
+type GetMaintenanceWindowInput struct {
+	_                   struct{}
+	MaintenanceWindowID *int64
+}
+
+// GetMaintenanceWindowOutput represents the output of a GetMaintenanceWindow operation.
+type GetMaintenanceWindowOutput struct {
+	_                 struct{}
+	MaintenanceWindow *MaintenanceWindow
+}
+
+// GetMaintenanceWindow gets the maintenance window with specified id. https://api.ilert.com/api-docs/#tag/Maintenance-Windows/paths/~1maintenance-windows~1{id}/get
+func (c *Client) GetMaintenanceWindow(input *GetMaintenanceWindowInput) (*GetMaintenanceWindowOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if input.MaintenanceWindowID == nil {
+		return nil, errors.New("maintenance window id is required")
+	}
+
+	resp, err := c.newRequest().Get(fmt.Sprintf("%s/%d", apiRoutes.maintenanceWindows, *input.MaintenanceWindowID))
+	if err != nil {
+		return nil, err
+	}
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
+		return nil, apiErr
+	}
+
+	maintenanceWindow := &MaintenanceWindow{}
+	err = decodeResponse("GetMaintenanceWindow", resp, maintenanceWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetMaintenanceWindowOutput{MaintenanceWindow: maintenanceWindow}, nil
+}
+
+// GetMaintenanceWindowsInput represents the input of a GetMaintenanceWindows operation.
+type GetMaintenanceWindowsInput struct {
+	_ struct{}
+}
+
+// GetMaintenanceWindowsOutput represents the output of a GetMaintenanceWindows operation.
+type GetMaintenanceWindowsOutput struct {
+	_                  struct{}
+	MaintenanceWindows []*MaintenanceWindow
+}
+
+// GetMaintenanceWindows lists maintenance windows. https://api.ilert.com/api-docs/#tag/Maintenance-Windows/paths/~1maintenance-windows/get
+func (c *Client) GetMaintenanceWindows(input *GetMaintenanceWindowsInput) (*GetMaintenanceWindowsOutput, error) {
+	resp, err := c.newRequest().Get(apiRoutes.maintenanceWindows)
+	if err != nil {
+		return nil, err
+	}
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
+		return nil, apiErr
+	}
+
+	maintenanceWindows := make([]*MaintenanceWindow, 0)
+	err = decodeResponse("GetMaintenanceWindows", resp, &maintenanceWindows)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetMaintenanceWindowsOutput{MaintenanceWindows: maintenanceWindows}, nil
+}
+
+// UpdateMaintenanceWindowInput represents the input of a UpdateMaintenanceWindow operation.
+type UpdateMaintenanceWindowInput struct {
+	_                   struct{}
+	MaintenanceWindowID *int64
+	MaintenanceWindow   *MaintenanceWindow
+}
+
+// UpdateMaintenanceWindowOutput represents the output of a UpdateMaintenanceWindow operation.
+type UpdateMaintenanceWindowOutput struct {
+	_                 struct{}
+	MaintenanceWindow *MaintenanceWindow
+}
+
+// UpdateMaintenanceWindow updates an existing maintenance window. https://api.ilert.com/api-docs/#tag/Maintenance-Windows/paths/~1maintenance-windows~1{id}/put
+func (c *Client) UpdateMaintenanceWindow(input *UpdateMaintenanceWindowInput) (*UpdateMaintenanceWindowOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if input.MaintenanceWindow == nil {
+		return nil, errors.New("maintenance window input is required")
+	}
+	if input.MaintenanceWindowID == nil {
+		return nil, errors.New("maintenance window id is required")
+	}
+	if err := c.validateMaintenanceWindow(input.MaintenanceWindow); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.newRequest().SetBody(input.MaintenanceWindow).Put(fmt.Sprintf("%s/%d", apiRoutes.maintenanceWindows, *input.MaintenanceWindowID))
+	if err != nil {
+		return nil, err
+	}
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
+		return nil, apiErr
+	}
+
+	maintenanceWindow := &MaintenanceWindow{}
+	err = decodeResponse("UpdateMaintenanceWindow", resp, maintenanceWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpdateMaintenanceWindowOutput{MaintenanceWindow: maintenanceWindow}, nil
+}
+
+// DeleteMaintenanceWindowInput represents the input of a DeleteMaintenanceWindow operation.
+type DeleteMaintenanceWindowInput struct {
+	_                   struct{}
+	MaintenanceWindowID *int64
+}
+
+// DeleteMaintenanceWindowOutput represents the output of a DeleteMaintenanceWindow operation.
+type DeleteMaintenanceWindowOutput struct {
+	_ struct{}
+}
+
+// DeleteMaintenanceWindow deletes the specified maintenance window. https://api.ilert.com/api-docs/#tag/Maintenance-Windows/paths/~1maintenance-windows~1{id}/delete
+func (c *Client) DeleteMaintenanceWindow(input *DeleteMaintenanceWindowInput) (*DeleteMaintenanceWindowOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+	if input.MaintenanceWindowID == nil {
+		return nil, errors.New("maintenance window id is required")
+	}
+
+	resp, err := c.newRequest().Delete(fmt.Sprintf("%s/%d", apiRoutes.maintenanceWindows, *input.MaintenanceWindowID))
+	if err != nil {
+		return nil, err
+	}
+	if apiErr := c.getGenericAPIError(resp, 204); apiErr != nil {
+		return nil, apiErr
+	}
+
+	return &DeleteMaintenanceWindowOutput{}, nil
+}
@@ -0,0 +1,54 @@
+package ilert
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// MetricsObserver receives events about the retry and rate limit behavior of the client,
+// e.g. to tune WithRetry and WithRateLimit. Implementations must be safe for concurrent use
+type MetricsObserver interface {
+	// ObserveRetry is called before a failed request is retried
+	ObserveRetry(event RetryEvent)
+
+	// ObserveRateLimitWait is called when a request was blocked by the rate limiter, with the time spent waiting
+	ObserveRateLimitWait(method string, url string, wait time.Duration)
+}
+
+// RetryEvent describes a retry of a failed request
+type RetryEvent struct {
+	Method     string
+	URL        string
+	Attempt    int    // number of the upcoming attempt, the first retry is attempt 2
+	StatusCode int    // status code of the failed attempt, 0 on network errors
+	Reason     string // e.g. "status 503" or the network error
+}
+
+// WithMetricsObserver registers an observer for retry attempts and rate limit waits
+func WithMetricsObserver(observer MetricsObserver) ClientOptions {
+	return func(c *Client) {
+		c.metricsObserver = observer
+	}
+}
+
+// retryHook reports the upcoming retry to the metrics observer
+func (c *Client) retryHook(r *resty.Response, err error) {
+	if c.metricsObserver == nil || r == nil || r.Request == nil {
+		return
+	}
+
+	event := RetryEvent{
+		Method:     r.Request.Method,
+		URL:        r.Request.URL,
+		Attempt:    r.Request.Attempt + 1,
+		StatusCode: r.StatusCode(),
+	}
+	if err != nil {
+		event.Reason = err.Error()
+	} else {
+		event.Reason = fmt.Sprintf("status %d", r.StatusCode())
+	}
+	c.metricsObserver.ObserveRetry(event)
+}
@@ -0,0 +1,43 @@
+package ilert
+
+import "sort"
+
+// MultiOrgClient holds a separately configured client per organization, e.g. for tools that manage the
+// organizations of several customers. Every client has its own http client, so authentication,
+// rate limit and retry budget state are isolated between organizations
+type MultiOrgClient struct {
+	clients map[string]*Client
+}
+
+// NewMultiOrgClient creates a client per organization id with the given options, e.g.
+//
+//	clients := ilert.NewMultiOrgClient(map[string][]ilert.ClientOptions{
+//		"org-a": {ilert.WithAPIToken(tokenA)},
+//		"org-b": {ilert.WithAPIToken(tokenB), ilert.WithRateLimit(5, time.Second)},
+//	})
+//	clients.ForOrg("org-a").GetIncidents(nil)
+//
+// Note: like NewClient every client is preconfigured from the ILERT_* environment variables,
+// pass the authentication of each organization explicitly
+func NewMultiOrgClient(options map[string][]ClientOptions) *MultiOrgClient {
+	m := &MultiOrgClient{clients: make(map[string]*Client, len(options))}
+	for orgID, orgOptions := range options {
+		m.clients[orgID] = NewClient(orgOptions...)
+	}
+	return m
+}
+
+// ForOrg returns the client of the organization, nil if the organization is unknown
+func (m *MultiOrgClient) ForOrg(orgID string) *Client {
+	return m.clients[orgID]
+}
+
+// Orgs returns the sorted ids of the organizations
+func (m *MultiOrgClient) Orgs() []string {
+	orgIDs := make([]string, 0, len(m.clients))
+	for orgID := range m.clients {
+		orgIDs = append(orgIDs, orgID)
+	}
+	sort.Strings(orgIDs)
+	return orgIDs
+}
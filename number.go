@@ -1,9 +1,5 @@
 package ilert
 
-import (
-	"encoding/json"
-)
-
 // Number definition https://api.ilert.com/api-docs/#tag/Numbers
 type Number struct {
 	CountryCode        string   `json:"countryCode"`
@@ -34,16 +30,16 @@ type GetNumbersOutput struct {
 
 // GetNumbers gets list available iLert phone numbers. https://api.ilert.com/api-docs/#tag/Numbers/paths/~1numbers/get
 func (c *Client) GetNumbers(input *GetNumbersInput) (*GetNumbersOutput, error) {
-	resp, err := c.httpClient.R().Get(apiRoutes.numbers)
+	resp, err := c.newRequest().Get(apiRoutes.numbers)
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	numbers := make([]*Number, 0)
-	err = json.Unmarshal(resp.Body(), &numbers)
+	err = decodeResponse("GetNumbers", resp, &numbers)
 	if err != nil {
 		return nil, err
 	}
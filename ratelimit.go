@@ -0,0 +1,133 @@
+package ilert
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// rateLimiter spaces requests evenly so that at most a given number of requests is sent per period
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// wait blocks until the next request may be sent and returns the time spent waiting
+func (l *rateLimiter) wait(ctx context.Context) (time.Duration, error) {
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := slot.Sub(now)
+	if delay <= 0 {
+		return 0, nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return delay, nil
+	case <-ctx.Done():
+		return time.Since(now), ctx.Err()
+	}
+}
+
+// pauseUntil delays all requests not sent yet until t, e.g. the reset time announced by the server
+func (l *rateLimiter) pauseUntil(t time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if t.After(l.next) {
+		l.next = t
+	}
+}
+
+// WithRateLimit limits the client to the given number of requests per period, e.g. WithRateLimit(10, time.Second).
+// Requests exceeding the limit are delayed, retries count against the limit as well.
+// When the server answers 429 with an X-RateLimit-Reset header, all following requests wait until the reset time
+func WithRateLimit(requests int, per time.Duration) ClientOptions {
+	return func(c *Client) {
+		if requests <= 0 || per <= 0 {
+			return
+		}
+		if c.rateLimiter == nil {
+			c.rateLimiter = &rateLimiter{}
+			c.httpClient.OnBeforeRequest(c.rateLimitMiddleware)
+			c.httpClient.OnAfterResponse(c.rateLimitResetMiddleware)
+		}
+		c.rateLimiter.interval = per / time.Duration(requests)
+	}
+}
+
+// rateLimitMiddleware delays the request according to the rate limit and reports the wait to the metrics observer
+func (c *Client) rateLimitMiddleware(_ *resty.Client, r *resty.Request) error {
+	waited, err := c.rateLimiter.wait(r.Context())
+	if waited > 0 && c.metricsObserver != nil {
+		c.metricsObserver.ObserveRateLimitWait(r.Method, r.URL, waited)
+	}
+	return err
+}
+
+// rateLimitResetMiddleware pauses the rate limiter until the reset time of a 429 response
+func (c *Client) rateLimitResetMiddleware(_ *resty.Client, resp *resty.Response) error {
+	if resp.StatusCode() != http.StatusTooManyRequests {
+		return nil
+	}
+	if reset, ok := parseRateLimitReset(resp.Header().Get("X-RateLimit-Reset"), time.Now()); ok {
+		c.rateLimiter.pauseUntil(reset)
+	}
+	return nil
+}
+
+// parseRateLimitReset parses the X-RateLimit-Reset header, either a unix timestamp or the seconds until the reset
+func parseRateLimitReset(value string, now time.Time) (time.Time, bool) {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds <= 0 {
+		return time.Time{}, false
+	}
+	// values below a year are relative, everything else is a unix timestamp
+	if seconds < 365*24*60*60 {
+		return now.Add(time.Duration(seconds) * time.Second), true
+	}
+	return time.Unix(seconds, 0), true
+}
+
+// retryAfter makes retries of 429 responses wait for the Retry-After header, capped at the max retry wait time.
+// Other responses use the exponential backoff
+func retryAfter(_ *resty.Client, resp *resty.Response) (time.Duration, error) {
+	if resp.StatusCode() != http.StatusTooManyRequests {
+		return 0, nil
+	}
+	wait, _ := parseRetryAfter(resp.Header().Get("Retry-After"), time.Now())
+	return wait, nil
+}
+
+// parseRetryAfter parses the Retry-After header, either a number of seconds or an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
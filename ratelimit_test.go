@@ -0,0 +1,106 @@
+package ilert
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryAfterTooManyRequests(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"status":429,"code":"TOO_MANY_REQUESTS","message":"rate limit exceeded"}`))
+			return
+		}
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIEndpoint(server.URL), WithRetry(2, time.Millisecond, 10*time.Millisecond))
+	output, err := client.GetIncident(&GetIncidentInput{IncidentID: Int64(1)})
+	if err != nil {
+		t.Fatalf("GetIncident: %v", err)
+	}
+	if output.Incident.ID != 1 {
+		t.Errorf("incident id = %d, want 1", output.Incident.ID)
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("got %d requests, want 2", got)
+	}
+}
+
+func TestRateLimitErrorWithoutRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"status":429,"code":"TOO_MANY_REQUESTS","message":"rate limit exceeded"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIEndpoint(server.URL), WithRetry(0, time.Millisecond, 10*time.Millisecond))
+	_, err := client.GetIncident(&GetIncidentInput{IncidentID: Int64(1)})
+
+	rateLimitErr := &RateLimitError{}
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("error = %v, want *RateLimitError", err)
+	}
+	if rateLimitErr.RetryAfter != 30*time.Second {
+		t.Errorf("RetryAfter = %s, want 30s", rateLimitErr.RetryAfter)
+	}
+	if rateLimitErr.Status != http.StatusTooManyRequests {
+		t.Errorf("Status = %d, want 429", rateLimitErr.Status)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"0", 0, true},
+		{"120", 2 * time.Minute, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{"Fri, 01 Jan 2021 12:00:30 GMT", 30 * time.Second, true},
+		{"Fri, 01 Jan 2021 11:59:00 GMT", 0, true},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseRetryAfter(%q) = %s, %t, want %s, %t", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestParseRateLimitReset(t *testing.T) {
+	now := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value  string
+		want   time.Time
+		wantOK bool
+	}{
+		{"", time.Time{}, false},
+		{"0", time.Time{}, false},
+		{"-5", time.Time{}, false},
+		{"later", time.Time{}, false},
+		{"60", now.Add(time.Minute), true},
+		{"1609502400", time.Unix(1609502400, 0), true},
+	}
+	for _, tt := range tests {
+		got, ok := parseRateLimitReset(tt.value, now)
+		if !got.Equal(tt.want) || ok != tt.wantOK {
+			t.Errorf("parseRateLimitReset(%q) = %s, %t, want %s, %t", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
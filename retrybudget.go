@@ -0,0 +1,109 @@
+package ilert
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	// retryBudgetWindow is the sliding window of the retry budget in seconds
+	retryBudgetWindow = 10
+
+	// retryBudgetMinRetries is the number of retries per window that are always allowed,
+	// so a client with little traffic can still retry
+	retryBudgetMinRetries = 10
+)
+
+// RetryBudgetObserver can be implemented by a MetricsObserver to be notified when a retry was refused
+// because the retry budget is exhausted
+type RetryBudgetObserver interface {
+	ObserveRetryBudgetExhausted(method string, url string)
+}
+
+// retryBudget limits retries to a ratio of the requests sent within a sliding window
+type retryBudget struct {
+	mu      sync.Mutex
+	ratio   float64
+	buckets [retryBudgetWindow]retryBudgetBucket
+}
+
+type retryBudgetBucket struct {
+	second   int64
+	requests int
+	retries  int
+}
+
+// bucket returns the bucket of the current second, resetting it if it belongs to an older window
+func (b *retryBudget) bucket(now time.Time) *retryBudgetBucket {
+	second := now.Unix()
+	bucket := &b.buckets[second%retryBudgetWindow]
+	if bucket.second != second {
+		*bucket = retryBudgetBucket{second: second}
+	}
+	return bucket
+}
+
+// recordRequest deposits a request into the budget
+func (b *retryBudget) recordRequest() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bucket(time.Now()).requests++
+}
+
+// tryRetry withdraws a retry from the budget, returns false if the budget is exhausted
+func (b *retryBudget) tryRetry() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	requests, retries := 0, 0
+	for _, bucket := range b.buckets {
+		if now.Unix()-bucket.second < retryBudgetWindow {
+			requests += bucket.requests
+			retries += bucket.retries
+		}
+	}
+	if float64(retries+1) > b.ratio*float64(requests)+retryBudgetMinRetries {
+		return false
+	}
+	b.bucket(now).retries++
+	return true
+}
+
+// WithRetryBudget limits the retries enabled by WithRetry to the given ratio of the requests sent within the last
+// 10 seconds (plus 10 retries that are always allowed), e.g. WithRetryBudget(0.2) allows one retry per five requests.
+// When the budget is exhausted failed requests are not retried and return their original error.
+// A MetricsObserver implementing RetryBudgetObserver is notified about every refused retry
+func WithRetryBudget(ratio float64) ClientOptions {
+	return func(c *Client) {
+		if ratio < 0 {
+			ratio = 0
+		}
+		if c.retryBudget == nil {
+			c.retryBudget = &retryBudget{}
+			c.httpClient.OnBeforeRequest(c.retryBudgetMiddleware)
+		}
+		c.retryBudget.ratio = ratio
+	}
+}
+
+// retryBudgetMiddleware records the first attempt of each request in the retry budget
+func (c *Client) retryBudgetMiddleware(_ *resty.Client, r *resty.Request) error {
+	if r.Attempt <= 1 {
+		c.retryBudget.recordRequest()
+	}
+	return nil
+}
+
+// allowRetry checks the retry budget, if any, before a request is retried
+func (c *Client) allowRetry(r *resty.Response) bool {
+	if c.retryBudget == nil || c.retryBudget.tryRetry() {
+		return true
+	}
+	if observer, ok := c.metricsObserver.(RetryBudgetObserver); ok {
+		observer.ObserveRetryBudgetExhausted(r.Request.Method, r.Request.URL)
+	}
+	return false
+}
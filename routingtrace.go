@@ -0,0 +1,119 @@
+package ilert
+
+import (
+	"fmt"
+	"sync"
+)
+
+// RoutingTrace describes where the notifications of an incident are routed to:
+// the alert source of the incident, the connections of the alert source and the connectors behind them
+type RoutingTrace struct {
+	Incident    *Incident
+	AlertSource *AlertSource // nil if the alert source does not exist anymore
+	Connections []RoutingTraceConnection
+
+	// links of the chain that could not be resolved, e.g. a deleted connector
+	Missing []string
+}
+
+// RoutingTraceConnection is a connection of the traced alert source and its connector
+type RoutingTraceConnection struct {
+	Connection *ConnectionOutput
+	Connector  *ConnectorOutput // nil if the connector does not exist anymore
+}
+
+// TraceIncidentRouting resolves the alert source of the incident with specified id, the connections of the
+// alert source and the connectors used by these connections. Alert source and connections as well as the
+// connectors are fetched concurrently. Links that do not exist anymore are listed in Missing instead of failing
+func (c *Client) TraceIncidentRouting(incidentID int64) (*RoutingTrace, error) {
+	incidentOutput, err := c.GetIncident(&GetIncidentInput{IncidentID: Int64(incidentID)})
+	if err != nil {
+		return nil, err
+	}
+	trace := &RoutingTrace{
+		Incident:    incidentOutput.Incident,
+		Connections: make([]RoutingTraceConnection, 0),
+		Missing:     make([]string, 0),
+	}
+	if trace.Incident.AlertSource == nil {
+		trace.Missing = append(trace.Missing, fmt.Sprintf("incident %d has no alert source", incidentID))
+		return trace, nil
+	}
+	alertSourceID := trace.Incident.AlertSource.ID
+
+	var (
+		wg                sync.WaitGroup
+		alertSourceOutput *GetAlertSourceOutput
+		alertSourceErr    error
+		connectionsOutput *GetConnectionsOutput
+		connectionsErr    error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		alertSourceOutput, alertSourceErr = c.GetAlertSource(&GetAlertSourceInput{AlertSourceID: Int64(alertSourceID)})
+	}()
+	go func() {
+		defer wg.Done()
+		connectionsOutput, connectionsErr = c.GetConnections(&GetConnectionsInput{})
+	}()
+	wg.Wait()
+
+	switch {
+	case alertSourceErr == nil:
+		trace.AlertSource = alertSourceOutput.AlertSource
+	case IsNotFound(alertSourceErr):
+		trace.Missing = append(trace.Missing, fmt.Sprintf("alert source %d not found", alertSourceID))
+	default:
+		return nil, alertSourceErr
+	}
+	if connectionsErr != nil {
+		return nil, connectionsErr
+	}
+
+	connectorIDs := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, connection := range connectionsOutput.Connections {
+		if !int64SliceContains(connection.AlertSourceIDs, alertSourceID) {
+			continue
+		}
+		trace.Connections = append(trace.Connections, RoutingTraceConnection{Connection: connection})
+		if connection.ConnectorID != "" && !seen[connection.ConnectorID] {
+			seen[connection.ConnectorID] = true
+			connectorIDs = append(connectorIDs, connection.ConnectorID)
+		}
+	}
+
+	connectors := make([]*ConnectorOutput, len(connectorIDs))
+	errs := make([]error, len(connectorIDs))
+	for i, connectorID := range connectorIDs {
+		wg.Add(1)
+		go func(i int, connectorID string) {
+			defer wg.Done()
+			output, err := c.GetConnector(&GetConnectorInput{ConnectorID: String(connectorID)})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			connectors[i] = output.Connector
+		}(i, connectorID)
+	}
+	wg.Wait()
+
+	connectorsByID := make(map[string]*ConnectorOutput)
+	for i, connectorID := range connectorIDs {
+		switch {
+		case errs[i] == nil:
+			connectorsByID[connectorID] = connectors[i]
+		case IsNotFound(errs[i]):
+			trace.Missing = append(trace.Missing, fmt.Sprintf("connector %s not found", connectorID))
+		default:
+			return nil, errs[i]
+		}
+	}
+	for i := range trace.Connections {
+		trace.Connections[i].Connector = connectorsByID[trace.Connections[i].Connection.ConnectorID]
+	}
+
+	return trace, nil
+}
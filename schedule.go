@@ -1,7 +1,6 @@
 package ilert
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
@@ -47,16 +46,16 @@ func (c *Client) GetSchedule(input *GetScheduleInput) (*GetScheduleOutput, error
 		return nil, errors.New("Schedule id is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d", apiRoutes.schedules, *input.ScheduleID))
+	resp, err := c.newRequest().Get(fmt.Sprintf("%s/%d", apiRoutes.schedules, *input.ScheduleID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	schedule := &Schedule{}
-	err = json.Unmarshal(resp.Body(), schedule)
+	err = decodeResponse("GetSchedule", resp, schedule)
 	if err != nil {
 		return nil, err
 	}
@@ -67,6 +66,15 @@ func (c *Client) GetSchedule(input *GetScheduleInput) (*GetScheduleOutput, error
 // GetSchedulesInput represents the input of a GetSchedules operation.
 type GetSchedulesInput struct {
 	_ struct{}
+
+	// an integer specifying the starting point (beginning with 0) when paging through a list of entities
+	StartIndex *int
+
+	// the maximum number of results when paging through a list of entities, at most MaxPageSize
+	MaxResults *int
+
+	// IDs of the teams the schedules belong to, see WithDefaultTeam
+	TeamIDs []*int64
 }
 
 // GetSchedulesOutput represents the output of a GetSchedules operation.
@@ -77,16 +85,35 @@ type GetSchedulesOutput struct {
 
 // GetSchedules gets list on-call schedules. https://api.ilert.com/api-docs/#tag/Schedules/paths/~1schedules/get
 func (c *Client) GetSchedules(input *GetSchedulesInput) (*GetSchedulesOutput, error) {
-	resp, err := c.httpClient.R().Get(apiRoutes.schedules)
+	if input == nil {
+		input = &GetSchedulesInput{}
+	}
+
+	q := url.Values{}
+	if input.StartIndex != nil {
+		q.Add("start-index", strconv.Itoa(*input.StartIndex))
+	}
+	if maxResults := c.clampPageSize("GetSchedules", input.MaxResults); maxResults != nil {
+		q.Add("max-results", strconv.Itoa(*maxResults))
+	}
+
+	for _, teamID := range c.teamIDsOrDefault(input.TeamIDs) {
+		if teamID == nil || *teamID <= 0 {
+			return nil, errors.New("team ids must be positive")
+		}
+		q.Add("team", strconv.FormatInt(*teamID, 10))
+	}
+
+	resp, err := c.newRequest().Get(fmt.Sprintf("%s?%s", apiRoutes.schedules, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	schedules := make([]*Schedule, 0)
-	err = json.Unmarshal(resp.Body(), &schedules)
+	err = decodeResponse("GetSchedules", resp, &schedules)
 	if err != nil {
 		return nil, err
 	}
@@ -94,6 +121,55 @@ func (c *Client) GetSchedules(input *GetSchedulesInput) (*GetSchedulesOutput, er
 	return &GetSchedulesOutput{Schedules: schedules}, nil
 }
 
+// getAllSchedules lists the schedules matching the input filters by requesting pages of MaxPageSize schedules
+// until a page has fewer schedules
+func (c *Client) getAllSchedules(input GetSchedulesInput) ([]*Schedule, error) {
+	input.StartIndex = Int(0)
+	input.MaxResults = Int(MaxPageSize)
+
+	schedules := make([]*Schedule, 0)
+	for {
+		output, err := c.GetSchedules(&input)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, output.Schedules...)
+		if len(output.Schedules) < MaxPageSize {
+			return schedules, nil
+		}
+		input.StartIndex = Int(*input.StartIndex + len(output.Schedules))
+	}
+}
+
+// GetScheduleByName gets the schedule with the specified name, searching all teams regardless of WithDefaultTeam.
+// Returns an error wrapping ErrNotFound or ErrMultipleMatches if the name does not identify exactly one schedule.
+func (c *Client) GetScheduleByName(name string) (*Schedule, error) {
+	if name == "" {
+		return nil, errors.New("schedule name is required")
+	}
+
+	schedules, err := c.getAllSchedules(GetSchedulesInput{TeamIDs: []*int64{}})
+	if err != nil {
+		return nil, err
+	}
+
+	var match *Schedule
+	for _, schedule := range schedules {
+		if schedule.Name != name {
+			continue
+		}
+		if match != nil {
+			return nil, fmt.Errorf("schedule %q: %w", name, ErrMultipleMatches)
+		}
+		match = schedule
+	}
+	if match == nil {
+		return nil, fmt.Errorf("schedule %q: %w", name, ErrNotFound)
+	}
+
+	return match, nil
+}
+
 // GetScheduleShiftsInput represents the input of a GetScheduleShifts operation.
 type GetScheduleShiftsInput struct {
 	_                struct{}
@@ -123,22 +199,22 @@ func (c *Client) GetScheduleShifts(input *GetScheduleShiftsInput) (*GetScheduleS
 		q.Add("from", *input.From)
 	}
 	if input.Until != nil {
-		q.Add("until", *input.From)
+		q.Add("until", *input.Until)
 	}
 	if input.ExcludeOverrides != nil {
 		q.Add("exclude-overrides", strconv.FormatBool(*input.ExcludeOverrides))
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d/shifts?%s", apiRoutes.schedules, *input.ScheduleID, q.Encode()))
+	resp, err := c.newRequest().Get(fmt.Sprintf("%s/%d/shifts?%s", apiRoutes.schedules, *input.ScheduleID, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	shifts := make([]*Shift, 0)
-	err = json.Unmarshal(resp.Body(), &shifts)
+	err = decodeResponse("GetScheduleShifts", resp, &shifts)
 	if err != nil {
 		return nil, err
 	}
@@ -167,16 +243,16 @@ func (c *Client) GetScheduleOverrides(input *GetScheduleOverridesInput) (*GetSch
 		return nil, errors.New("Schedule id is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d/overrides", apiRoutes.schedules, *input.ScheduleID))
+	resp, err := c.newRequest().Get(fmt.Sprintf("%s/%d/overrides", apiRoutes.schedules, *input.ScheduleID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	overrides := make([]*Shift, 0)
-	err = json.Unmarshal(resp.Body(), &overrides)
+	err = decodeResponse("GetScheduleOverrides", resp, &overrides)
 	if err != nil {
 		return nil, err
 	}
@@ -205,11 +281,11 @@ func (c *Client) GetScheduleUserOnCall(input *GetScheduleUserOnCallInput) (*GetS
 		return nil, errors.New("Schedule id is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d/user-on-call", apiRoutes.schedules, *input.ScheduleID))
+	resp, err := c.newRequest().Get(fmt.Sprintf("%s/%d/user-on-call", apiRoutes.schedules, *input.ScheduleID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200, 204); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200, 204); apiErr != nil {
 		return nil, apiErr
 	}
 
@@ -218,7 +294,7 @@ func (c *Client) GetScheduleUserOnCall(input *GetScheduleUserOnCallInput) (*GetS
 	}
 
 	shift := &Shift{}
-	err = json.Unmarshal(resp.Body(), shift)
+	err = decodeResponse("GetScheduleUserOnCall", resp, shift)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,35 @@
+package ilert
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestGetScheduleShiftsUntil(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIEndpoint(server.URL))
+	_, err := client.GetScheduleShifts(&GetScheduleShiftsInput{
+		ScheduleID: Int64(1),
+		From:       String("2021-01-01T00:00:00Z"),
+		Until:      String("2021-02-01T00:00:00Z"),
+	})
+	if err != nil {
+		t.Fatalf("GetScheduleShifts: %v", err)
+	}
+
+	if got := query.Get("from"); got != "2021-01-01T00:00:00Z" {
+		t.Errorf("from = %q, want %q", got, "2021-01-01T00:00:00Z")
+	}
+	if got := query.Get("until"); got != "2021-02-01T00:00:00Z" {
+		t.Errorf("until = %q, want %q", got, "2021-02-01T00:00:00Z")
+	}
+}
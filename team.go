@@ -1,7 +1,6 @@
 package ilert
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 )
@@ -87,16 +86,16 @@ func (c *Client) CreateTeam(input *CreateTeamInput) (*CreateTeamOutput, error) {
 	if input.Team == nil {
 		return nil, errors.New("Team input is required")
 	}
-	resp, err := c.httpClient.R().SetBody(input.Team).Post(apiRoutes.teams)
+	resp, err := c.newRequest().SetBody(input.Team).Post(apiRoutes.teams)
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 201); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 201); apiErr != nil {
 		return nil, apiErr
 	}
 
 	team := &Team{}
-	err = json.Unmarshal(resp.Body(), team)
+	err = decodeResponse("CreateTeam", resp, team)
 	if err != nil {
 		return nil, err
 	}
@@ -125,16 +124,16 @@ func (c *Client) GetTeam(input *GetTeamInput) (*GetTeamOutput, error) {
 		return nil, errors.New("Team id is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d", apiRoutes.teams, *input.TeamID))
+	resp, err := c.newRequest().Get(fmt.Sprintf("%s/%d", apiRoutes.teams, *input.TeamID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	team := &Team{}
-	err = json.Unmarshal(resp.Body(), team)
+	err = decodeResponse("GetTeam", resp, team)
 	if err != nil {
 		return nil, err
 	}
@@ -155,16 +154,16 @@ type GetTeamsOutput struct {
 
 // GetTeams gets list teams. https://api.ilert.com/api-docs/#tag/Teams/paths/~1teams/get
 func (c *Client) GetTeams(input *GetTeamsInput) (*GetTeamsOutput, error) {
-	resp, err := c.httpClient.R().Get(apiRoutes.teams)
+	resp, err := c.newRequest().Get(apiRoutes.teams)
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	teams := make([]*Team, 0)
-	err = json.Unmarshal(resp.Body(), &teams)
+	err = decodeResponse("GetTeams", resp, &teams)
 	if err != nil {
 		return nil, err
 	}
@@ -197,16 +196,16 @@ func (c *Client) UpdateTeam(input *UpdateTeamInput) (*UpdateTeamOutput, error) {
 		return nil, errors.New("Team id is required")
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.Team).Put(fmt.Sprintf("%s/%d", apiRoutes.teams, *input.TeamID))
+	resp, err := c.newRequest().SetBody(input.Team).Put(fmt.Sprintf("%s/%d", apiRoutes.teams, *input.TeamID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	team := &Team{}
-	err = json.Unmarshal(resp.Body(), team)
+	err = decodeResponse("UpdateTeam", resp, team)
 	if err != nil {
 		return nil, err
 	}
@@ -234,11 +233,11 @@ func (c *Client) DeleteTeam(input *DeleteTeamInput) (*DeleteTeamOutput, error) {
 		return nil, errors.New("Team id is required")
 	}
 
-	resp, err := c.httpClient.R().Delete(fmt.Sprintf("%s/%d", apiRoutes.teams, *input.TeamID))
+	resp, err := c.newRequest().Delete(fmt.Sprintf("%s/%d", apiRoutes.teams, *input.TeamID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 204); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 204); apiErr != nil {
 		return nil, apiErr
 	}
 
@@ -0,0 +1,90 @@
+package ilert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var terraformLabelInvalidChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// ExportEscalationPolicyHCL writes the escalation policy as a Terraform resource block of the iLert provider
+// (registry.terraform.io/providers/iLert/ilert), e.g.
+//
+//	resource "ilert_escalation_policy" "on_call" {
+//	  name = "On call"
+//
+//	  escalation_rule {
+//	    escalation_timeout = 15
+//	    user               = "123"
+//	  }
+//	}
+//
+// The resource label is derived from the policy name. Users, schedules and teams are referenced by their ids,
+// replace them with references to the corresponding ilert_user, ilert_schedule and ilert_team resources
+// when those are managed by Terraform as well
+func ExportEscalationPolicyHCL(policy *EscalationPolicy, w io.Writer) error {
+	if policy == nil {
+		return errors.New("escalation policy is required")
+	}
+	if w == nil {
+		return errors.New("writer is required")
+	}
+
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, "resource \"ilert_escalation_policy\" %s {\n", hclString(terraformLabel(policy.Name, policy.ID)))
+	fmt.Fprintf(b, "  name = %s\n", hclString(policy.Name))
+	if policy.Repeating {
+		fmt.Fprintf(b, "  repeating = true\n")
+		fmt.Fprintf(b, "  frequency = %d\n", policy.Frequency)
+	}
+
+	for _, rule := range policy.EscalationRules {
+		fmt.Fprintf(b, "\n  escalation_rule {\n")
+		fmt.Fprintf(b, "    escalation_timeout = %d\n", rule.EscalationTimeout)
+		if rule.User != nil {
+			fmt.Fprintf(b, "    user               = %s\n", hclString(strconv.FormatInt(rule.User.ID, 10)))
+		}
+		if rule.Schedule != nil {
+			fmt.Fprintf(b, "    schedule           = %s\n", hclString(strconv.FormatInt(rule.Schedule.ID, 10)))
+		}
+		for _, user := range rule.Users {
+			fmt.Fprintf(b, "\n    users {\n      id = %s\n    }\n", hclString(strconv.FormatInt(user.ID, 10)))
+		}
+		for _, schedule := range rule.Schedules {
+			fmt.Fprintf(b, "\n    schedules {\n      id = %s\n    }\n", hclString(strconv.FormatInt(schedule.ID, 10)))
+		}
+		fmt.Fprintf(b, "  }\n")
+	}
+
+	for _, team := range policy.Teams {
+		fmt.Fprintf(b, "\n  team {\n    id = %d\n  }\n", team.ID)
+	}
+	fmt.Fprintf(b, "}\n")
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// terraformLabel converts a resource name into a valid Terraform resource label
+func terraformLabel(name string, id int64) string {
+	label := strings.Trim(terraformLabelInvalidChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if label == "" {
+		return fmt.Sprintf("escalation_policy_%d", id)
+	}
+	if label[0] >= '0' && label[0] <= '9' {
+		label = "_" + label
+	}
+	return label
+}
+
+// hclString quotes the string as HCL string literal, template sequences are escaped
+func hclString(s string) string {
+	s = strings.ReplaceAll(s, "${", "$${")
+	s = strings.ReplaceAll(s, "%{", "%%{")
+	return strconv.Quote(s)
+}
@@ -1,7 +1,6 @@
 package ilert
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 )
@@ -17,7 +16,7 @@ type UptimeMonitor struct {
 	TimeoutMs                       int                      `json:"timeoutMs,omitempty"`                       // default: 30000
 	CreateIncidentAfterFailedChecks int                      `json:"createIncidentAfterFailedChecks,omitempty"` // default: 1
 	EscalationPolicy                *EscalationPolicy        `json:"escalationPolicy,omitempty"`
-	Paused                          bool                     `json:"paused,omitempty"`   // default: false
+	Paused                          bool                     `json:"paused"`             // default: false
 	EmbedURL                        string                   `json:"embedURL,omitempty"` // read only
 	ShareURL                        string                   `json:"shareURL,omitempty"` // read only
 	Status                          string                   `json:"status,omitempty"`
@@ -88,16 +87,16 @@ func (c *Client) CreateUptimeMonitor(input *CreateUptimeMonitorInput) (*CreateUp
 	if input.UptimeMonitor == nil {
 		return nil, errors.New("uptime monitor input is required")
 	}
-	resp, err := c.httpClient.R().SetBody(input.UptimeMonitor).Post(apiRoutes.uptimeMonitors)
+	resp, err := c.newRequest().SetBody(input.UptimeMonitor).Post(apiRoutes.uptimeMonitors)
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 201); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 201); apiErr != nil {
 		return nil, apiErr
 	}
 
 	uptimeMonitor := &UptimeMonitor{}
-	err = json.Unmarshal(resp.Body(), uptimeMonitor)
+	err = decodeResponse("CreateUptimeMonitor", resp, uptimeMonitor)
 	if err != nil {
 		return nil, err
 	}
@@ -126,16 +125,16 @@ func (c *Client) GetUptimeMonitor(input *GetUptimeMonitorInput) (*GetUptimeMonit
 		return nil, errors.New("uptime monitor id is required")
 	}
 
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/%d", apiRoutes.uptimeMonitors, *input.UptimeMonitorID))
+	resp, err := c.newRequest().Get(fmt.Sprintf("%s/%d", apiRoutes.uptimeMonitors, *input.UptimeMonitorID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	uptimeMonitor := &UptimeMonitor{}
-	err = json.Unmarshal(resp.Body(), uptimeMonitor)
+	err = decodeResponse("GetUptimeMonitor", resp, uptimeMonitor)
 	if err != nil {
 		return nil, err
 	}
@@ -156,16 +155,16 @@ type GetUptimeMonitorsOutput struct {
 
 // GetUptimeMonitors gets list uptime monitors. https://api.ilert.com/api-docs/#tag/Uptime-Monitors/paths/~1uptime-monitors/get
 func (c *Client) GetUptimeMonitors(input *GetUptimeMonitorsInput) (*GetUptimeMonitorsOutput, error) {
-	resp, err := c.httpClient.R().Get(apiRoutes.uptimeMonitors)
+	resp, err := c.newRequest().Get(apiRoutes.uptimeMonitors)
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	uptimeMonitors := make([]*UptimeMonitor, 0)
-	err = json.Unmarshal(resp.Body(), &uptimeMonitors)
+	err = decodeResponse("GetUptimeMonitors", resp, &uptimeMonitors)
 	if err != nil {
 		return nil, err
 	}
@@ -198,16 +197,16 @@ func (c *Client) UpdateUptimeMonitor(input *UpdateUptimeMonitorInput) (*UpdateUp
 		return nil, errors.New("uptime monitor id is required")
 	}
 
-	resp, err := c.httpClient.R().SetBody(input.UptimeMonitor).Put(fmt.Sprintf("%s/%d", apiRoutes.uptimeMonitors, *input.UptimeMonitorID))
+	resp, err := c.newRequest().SetBody(input.UptimeMonitor).Put(fmt.Sprintf("%s/%d", apiRoutes.uptimeMonitors, *input.UptimeMonitorID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	uptimeMonitor := &UptimeMonitor{}
-	err = json.Unmarshal(resp.Body(), uptimeMonitor)
+	err = decodeResponse("UpdateUptimeMonitor", resp, uptimeMonitor)
 	if err != nil {
 		return nil, err
 	}
@@ -215,6 +214,85 @@ func (c *Client) UpdateUptimeMonitor(input *UpdateUptimeMonitorInput) (*UpdateUp
 	return &UpdateUptimeMonitorOutput{UptimeMonitor: uptimeMonitor}, nil
 }
 
+// PauseUptimeMonitorInput represents the input of a PauseUptimeMonitor operation.
+type PauseUptimeMonitorInput struct {
+	_               struct{}
+	UptimeMonitorID *int64
+}
+
+// PauseUptimeMonitorOutput represents the output of a PauseUptimeMonitor operation.
+type PauseUptimeMonitorOutput struct {
+	_             struct{}
+	UptimeMonitor *UptimeMonitor
+}
+
+// PauseUptimeMonitor pauses the uptime monitor with specified id, a paused monitor does not create incidents
+func (c *Client) PauseUptimeMonitor(input *PauseUptimeMonitorInput) (*PauseUptimeMonitorOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+
+	uptimeMonitor, err := c.setUptimeMonitorPaused(input.UptimeMonitorID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PauseUptimeMonitorOutput{UptimeMonitor: uptimeMonitor}, nil
+}
+
+// ResumeUptimeMonitorInput represents the input of a ResumeUptimeMonitor operation.
+type ResumeUptimeMonitorInput struct {
+	_               struct{}
+	UptimeMonitorID *int64
+}
+
+// ResumeUptimeMonitorOutput represents the output of a ResumeUptimeMonitor operation.
+type ResumeUptimeMonitorOutput struct {
+	_             struct{}
+	UptimeMonitor *UptimeMonitor
+}
+
+// ResumeUptimeMonitor resumes the paused uptime monitor with specified id
+func (c *Client) ResumeUptimeMonitor(input *ResumeUptimeMonitorInput) (*ResumeUptimeMonitorOutput, error) {
+	if input == nil {
+		return nil, errors.New("input is required")
+	}
+
+	uptimeMonitor, err := c.setUptimeMonitorPaused(input.UptimeMonitorID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResumeUptimeMonitorOutput{UptimeMonitor: uptimeMonitor}, nil
+}
+
+// setUptimeMonitorPaused fetches the uptime monitor and updates its paused state
+func (c *Client) setUptimeMonitorPaused(uptimeMonitorID *int64, paused bool) (*UptimeMonitor, error) {
+	if uptimeMonitorID == nil {
+		return nil, errors.New("uptime monitor id is required")
+	}
+
+	getOutput, err := c.GetUptimeMonitor(&GetUptimeMonitorInput{UptimeMonitorID: uptimeMonitorID})
+	if err != nil {
+		return nil, err
+	}
+	uptimeMonitor := getOutput.UptimeMonitor
+	if uptimeMonitor.Paused == paused {
+		return uptimeMonitor, nil
+	}
+	uptimeMonitor.Paused = paused
+
+	updateOutput, err := c.UpdateUptimeMonitor(&UpdateUptimeMonitorInput{
+		UptimeMonitorID: uptimeMonitorID,
+		UptimeMonitor:   uptimeMonitor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updateOutput.UptimeMonitor, nil
+}
+
 // DeleteUptimeMonitorInput represents the input of a DeleteUptimeMonitor operation.
 type DeleteUptimeMonitorInput struct {
 	_               struct{}
@@ -235,11 +313,11 @@ func (c *Client) DeleteUptimeMonitor(input *DeleteUptimeMonitorInput) (*DeleteUp
 		return nil, errors.New("UptimeMonitor id is required")
 	}
 
-	resp, err := c.httpClient.R().Delete(fmt.Sprintf("%s/%d", apiRoutes.uptimeMonitors, *input.UptimeMonitorID))
+	resp, err := c.newRequest().Delete(fmt.Sprintf("%s/%d", apiRoutes.uptimeMonitors, *input.UptimeMonitorID))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 204); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 204); apiErr != nil {
 		return nil, apiErr
 	}
 
@@ -259,16 +337,16 @@ type GetUptimeMonitorsCountOutput struct {
 
 // GetUptimeMonitorsCount gets list uptime monitors. https://api.ilert.com/api-docs/#tag/Uptime-Monitors/paths/~1uptime-monitors~1count/get
 func (c *Client) GetUptimeMonitorsCount(input *GetUptimeMonitorsCountInput) (*GetUptimeMonitorsCountOutput, error) {
-	resp, err := c.httpClient.R().Get(fmt.Sprintf("%s/count", apiRoutes.uptimeMonitors))
+	resp, err := c.newRequest().Get(fmt.Sprintf("%s/count", apiRoutes.uptimeMonitors))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	body := &GenericCountResponse{}
-	err = json.Unmarshal(resp.Body(), body)
+	err = decodeResponse("GetUptimeMonitorsCount", resp, body)
 	if err != nil {
 		return nil, err
 	}
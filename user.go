@@ -1,9 +1,11 @@
 package ilert
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 )
 
 // User definition https://api.ilert.com/api-docs/#!/Users
@@ -20,6 +22,7 @@ type User struct {
 	Timezone                                  string                         `json:"timezone,omitempty"`
 	Language                                  string                         `json:"language,omitempty"`
 	Role                                      string                         `json:"role,omitempty"`
+	Status                                    string                         `json:"status,omitempty"` // read only, e.g. ACTIVE
 	NotificationPreferences                   []NotificationPreference       `json:"notificationPreferences,omitempty"`
 	LowNotificationPreferences                []NotificationPreference       `json:"lowPriorityNotificationPreferences,omitempty"`
 	OnCallNotificationPreferences             []OnCallNotificationPreference `json:"onCallNotificationPreferences,omitempty"`
@@ -56,6 +59,28 @@ var UserRole = struct {
 	Stakeholder: "STAKEHOLDER",
 }
 
+// UserRoleAll defines user roles list
+var UserRoleAll = []string{
+	UserRole.User,
+	UserRole.Admin,
+	UserRole.Stakeholder,
+}
+
+// UserStatuses defines user statuses
+var UserStatuses = struct {
+	Active      string
+	Deactivated string
+}{
+	Active:      "ACTIVE",
+	Deactivated: "DEACTIVATED",
+}
+
+// UserStatusesAll defines user statuses list
+var UserStatusesAll = []string{
+	UserStatuses.Active,
+	UserStatuses.Deactivated,
+}
+
 // UserIncidentUpdateStates defines user incident update states
 var UserIncidentUpdateStates = struct {
 	Accepted  string
@@ -113,16 +138,16 @@ func (c *Client) CreateUser(input *CreateUserInput) (*CreateUserOutput, error) {
 	if input.User == nil {
 		return nil, errors.New("User input is required")
 	}
-	resp, err := c.httpClient.R().SetBody(input.User).Post(apiRoutes.users)
+	resp, err := c.newRequest().SetBody(input.User).Post(apiRoutes.users)
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 201); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 201); apiErr != nil {
 		return nil, apiErr
 	}
 
 	user := &User{}
-	err = json.Unmarshal(resp.Body(), user)
+	err = decodeResponse("CreateUser", resp, user)
 	if err != nil {
 		return nil, err
 	}
@@ -149,6 +174,23 @@ func (c *Client) GetCurrentUser() (*GetUserOutput, error) {
 	return c.GetUser(input)
 }
 
+// getCurrentUserID gets the id of the currently authenticated user, the id is cached on the client after the first lookup
+func (c *Client) getCurrentUserID() (int64, error) {
+	c.currentUser.mu.Lock()
+	defer c.currentUser.mu.Unlock()
+	if c.currentUser.id != nil {
+		return *c.currentUser.id, nil
+	}
+
+	output, err := c.GetCurrentUser()
+	if err != nil {
+		return 0, err
+	}
+	c.currentUser.id = Int64(output.User.ID)
+
+	return output.User.ID, nil
+}
+
 // GetUser gets information about a user including contact methods and notification preferences. https://api.ilert.com/api-docs/#tag/Users/paths/~1users~1{user-id}/get
 func (c *Client) GetUser(input *GetUserInput) (*GetUserOutput, error) {
 	if input == nil {
@@ -163,16 +205,16 @@ func (c *Client) GetUser(input *GetUserInput) (*GetUserOutput, error) {
 	} else {
 		url = fmt.Sprintf("%s/%s", apiRoutes.users, *input.Username)
 	}
-	resp, err := c.httpClient.R().Get(url)
+	resp, err := c.newRequest().Get(url)
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	user := &User{}
-	err = json.Unmarshal(resp.Body(), user)
+	err = decodeResponse("GetUser", resp, user)
 	if err != nil {
 		return nil, err
 	}
@@ -183,6 +225,18 @@ func (c *Client) GetUser(input *GetUserInput) (*GetUserOutput, error) {
 // GetUsersInput represents the input of a GetUsers operation.
 type GetUsersInput struct {
 	_ struct{}
+
+	// an integer specifying the starting point (beginning with 0) when paging through a list of entities
+	StartIndex *int
+
+	// the maximum number of results when paging through a list of entities, at most MaxPageSize
+	MaxResults *int
+
+	// (optional) role of the users, one of UserRoleAll
+	Role *string
+
+	// (optional) status of the users, one of UserStatusesAll
+	Status *string
 }
 
 // GetUsersOutput represents the output of a GetUsers operation.
@@ -193,16 +247,40 @@ type GetUsersOutput struct {
 
 // GetUsers lists existing users. https://api.ilert.com/api-docs/#tag/Users/paths/~1users/get
 func (c *Client) GetUsers(input *GetUsersInput) (*GetUsersOutput, error) {
-	resp, err := c.httpClient.R().Get(apiRoutes.users)
+	if input == nil {
+		input = &GetUsersInput{}
+	}
+
+	q := url.Values{}
+	if input.StartIndex != nil {
+		q.Add("start-index", strconv.Itoa(*input.StartIndex))
+	}
+	if maxResults := c.clampPageSize("GetUsers", input.MaxResults); maxResults != nil {
+		q.Add("max-results", strconv.Itoa(*maxResults))
+	}
+	if input.Role != nil {
+		if !stringSliceContains(UserRoleAll, *input.Role) {
+			return nil, fmt.Errorf("invalid user role %q", *input.Role)
+		}
+		q.Add("role", *input.Role)
+	}
+	if input.Status != nil {
+		if !stringSliceContains(UserStatusesAll, *input.Status) {
+			return nil, fmt.Errorf("invalid user status %q", *input.Status)
+		}
+		q.Add("status", *input.Status)
+	}
+
+	resp, err := c.newRequest().Get(fmt.Sprintf("%s?%s", apiRoutes.users, q.Encode()))
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	users := make([]*User, 0)
-	err = json.Unmarshal(resp.Body(), &users)
+	err = decodeResponse("GetUsers", resp, &users)
 	if err != nil {
 		return nil, err
 	}
@@ -210,6 +288,26 @@ func (c *Client) GetUsers(input *GetUsersInput) (*GetUsersOutput, error) {
 	return &GetUsersOutput{Users: users}, nil
 }
 
+// getAllUsers lists the users matching the input filters by requesting pages of MaxPageSize users
+// until a page has fewer users
+func (c *Client) getAllUsers(input GetUsersInput) ([]*User, error) {
+	input.StartIndex = Int(0)
+	input.MaxResults = Int(MaxPageSize)
+
+	users := make([]*User, 0)
+	for {
+		output, err := c.GetUsers(&input)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, output.Users...)
+		if len(output.Users) < MaxPageSize {
+			return users, nil
+		}
+		input.StartIndex = Int(*input.StartIndex + len(output.Users))
+	}
+}
+
 // UpdateUserInput represents the input of a UpdateUser operation.
 type UpdateUserInput struct {
 	_        struct{}
@@ -250,16 +348,16 @@ func (c *Client) UpdateUser(input *UpdateUserInput) (*UpdateUserOutput, error) {
 	} else {
 		url = fmt.Sprintf("%s/%s", apiRoutes.users, *input.Username)
 	}
-	resp, err := c.httpClient.R().SetBody(input.User).Put(url)
+	resp, err := c.newRequest().SetBody(input.User).Put(url)
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 200); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 200); apiErr != nil {
 		return nil, apiErr
 	}
 
 	user := &User{}
-	err = json.Unmarshal(resp.Body(), user)
+	err = decodeResponse("UpdateUser", resp, user)
 	if err != nil {
 		return nil, err
 	}
@@ -293,13 +391,42 @@ func (c *Client) DeleteUser(input *DeleteUserInput) (*DeleteUserOutput, error) {
 	} else {
 		url = fmt.Sprintf("%s/%s", apiRoutes.users, *input.Username)
 	}
-	resp, err := c.httpClient.R().Delete(url)
+	resp, err := c.newRequest().Delete(url)
 	if err != nil {
 		return nil, err
 	}
-	if apiErr := getGenericAPIError(resp, 204); apiErr != nil {
+	if apiErr := c.getGenericAPIError(resp, 204); apiErr != nil {
 		return nil, apiErr
 	}
 
 	return &DeleteUserOutput{}, nil
 }
+
+// GetUserByEmail gets the user with the specified email address. The email is matched case-insensitively.
+// Returns an error wrapping ErrNotFound or ErrMultipleMatches if the email does not identify exactly one user.
+func (c *Client) GetUserByEmail(email string) (*User, error) {
+	if email == "" {
+		return nil, errors.New("User email is required")
+	}
+
+	users, err := c.getAllUsers(GetUsersInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	var match *User
+	for _, user := range users {
+		if !strings.EqualFold(user.Email, email) {
+			continue
+		}
+		if match != nil {
+			return nil, fmt.Errorf("user %q: %w", email, ErrMultipleMatches)
+		}
+		match = user
+	}
+	if match == nil {
+		return nil, fmt.Errorf("user %q: %w", email, ErrNotFound)
+	}
+
+	return match, nil
+}